@@ -0,0 +1,179 @@
+package llmcostops
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// AlertService handles webhook subscriptions for cost and budget alerts.
+type AlertService struct {
+	client *Client
+}
+
+// AlertCondition describes the condition that triggers an AlertRule.
+type AlertCondition struct {
+	// Type is one of "cost_exceeds", "trend", or "provider_share".
+	Type string `json:"type"`
+
+	// OrganizationID/ProjectID scope the condition; empty means all.
+	OrganizationID string `json:"organization_id,omitempty"`
+	ProjectID      string `json:"project_id,omitempty"`
+
+	// Threshold is used by cost_exceeds (a cost in the rule's currency) and
+	// provider_share (a fraction between 0 and 1).
+	Threshold float64 `json:"threshold,omitempty"`
+
+	// Provider is used by provider_share.
+	Provider Provider `json:"provider,omitempty"`
+
+	// Direction and Confidence are used by trend, e.g. direction=increasing
+	// with confidence>0.8 sustained for DurationDays.
+	Direction    string  `json:"direction,omitempty"`
+	Confidence   float64 `json:"confidence,omitempty"`
+	DurationDays int     `json:"duration_days,omitempty"`
+}
+
+// AlertTarget is a single delivery destination for a firing alert.
+type AlertTarget struct {
+	// Type is one of "url", "email", or "slack".
+	Type string `json:"type"`
+	// Value is the URL, email address, or Slack webhook URL.
+	Value string `json:"value"`
+}
+
+// AlertRule defines when an alert fires and where it is delivered.
+type AlertRule struct {
+	Name      string         `json:"name"`
+	Condition AlertCondition `json:"condition"`
+	Targets   []AlertTarget  `json:"targets"`
+	Enabled   bool           `json:"enabled"`
+}
+
+// Subscription is the server's record of a registered AlertRule, including
+// the HMAC secret used to sign deliveries.
+type Subscription struct {
+	ID        string    `json:"id"`
+	Rule      AlertRule `json:"rule"`
+	Secret    string    `json:"secret"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Subscribe registers a new alert rule.
+func (s *AlertService) Subscribe(ctx context.Context, rule AlertRule) (*Subscription, error) {
+	if rule.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrBadRequest)
+	}
+	if rule.Condition.Type == "" {
+		return nil, fmt.Errorf("%w: condition type is required", ErrBadRequest)
+	}
+
+	req, err := s.client.newRequest(http.MethodPost, "/api/v1/alerts/subscriptions", rule)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Subscription
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// List retrieves all alert subscriptions.
+func (s *AlertService) List(ctx context.Context) ([]Subscription, error) {
+	req, err := s.client.newRequest(http.MethodGet, "/api/v1/alerts/subscriptions", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Subscription
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Delete removes an alert subscription by ID.
+func (s *AlertService) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: id is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/alerts/subscriptions/%s", url.PathEscape(id))
+	req, err := s.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.do(ctx, req, nil)
+}
+
+// AlertEvent is the payload delivered to a webhook target when a
+// subscription's condition fires.
+type AlertEvent struct {
+	SubscriptionID string         `json:"subscription_id"`
+	RuleName       string         `json:"rule_name"`
+	FiredAt        time.Time      `json:"fired_at"`
+	Condition      AlertCondition `json:"condition"`
+	ObservedValue  float64        `json:"observed_value"`
+	Message        string         `json:"message"`
+}
+
+// ValidatePayload verifies the HMAC-SHA256 signature on an inbound webhook
+// delivery, where header is the value of the X-CostOps-Signature header and
+// body is the raw request body.
+func (s *AlertService) ValidatePayload(header string, body []byte, secret string) error {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(header)) {
+		return fmt.Errorf("llmcostops: invalid webhook signature")
+	}
+	return nil
+}
+
+// DecodeEvent decodes a validated webhook delivery body into an AlertEvent.
+func (s *AlertService) DecodeEvent(body []byte) (*AlertEvent, error) {
+	var event AlertEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode alert event: %w", err)
+	}
+	return &event, nil
+}
+
+// Receiver returns an http.Handler that verifies the X-CostOps-Signature
+// header against secret, decodes the body into an AlertEvent, and invokes
+// handler. Signature or decode failures result in a 400 response and
+// handler is not called.
+func (s *AlertService) Receiver(secret string, handler func(*AlertEvent)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+
+		if err := s.ValidatePayload(r.Header.Get("X-CostOps-Signature"), body, secret); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		event, err := s.DecodeEvent(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		handler(event)
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,311 @@
+package llmcostops
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultAnomalyWindowSize is how many trailing buckets of a series
+// AnomaliesService.Detect considers when it has more history than it needs,
+// the N in the seasonal-hybrid ESD-style algorithm it runs.
+const DefaultAnomalyWindowSize = 30
+
+// DefaultAnomalyZThreshold is the modified z-score above which a bucket is
+// flagged as an outlier.
+const DefaultAnomalyZThreshold = 3.5
+
+// madConsistencyConstant scales a sample's median absolute deviation so it
+// estimates standard deviation consistently for normally-distributed data;
+// it's the constant Iglewicz & Hoaglin's modified z-score uses.
+const madConsistencyConstant = 1.4826
+
+// AnomalyDetectorConfig tunes AnomaliesService.Detect's rolling
+// median-absolute-deviation detector. Zero fields take their defaults, so
+// the zero value behaves the same as passing nil to Detect.
+type AnomalyDetectorConfig struct {
+	// WindowSize is N, the number of trailing buckets considered per
+	// series. Defaults to DefaultAnomalyWindowSize.
+	WindowSize int
+
+	// ZThreshold is Z; a bucket is flagged when its modified z-score
+	// exceeds it. Defaults to DefaultAnomalyZThreshold.
+	ZThreshold float64
+
+	// MaxOutliers caps how many buckets Detect will flag per series in one
+	// pass, so one pathological series can't dominate the result. Zero or
+	// negative means unbounded (limited only by WindowSize).
+	MaxOutliers int
+}
+
+func (c *AnomalyDetectorConfig) withDefaults() AnomalyDetectorConfig {
+	cfg := AnomalyDetectorConfig{
+		WindowSize:  DefaultAnomalyWindowSize,
+		ZThreshold:  DefaultAnomalyZThreshold,
+		MaxOutliers: 0,
+	}
+	if c != nil {
+		if c.WindowSize > 0 {
+			cfg.WindowSize = c.WindowSize
+		}
+		if c.ZThreshold > 0 {
+			cfg.ZThreshold = c.ZThreshold
+		}
+		cfg.MaxOutliers = c.MaxOutliers
+	}
+	return cfg
+}
+
+// Anomaly is a single detected outlier bucket in a cost series.
+type Anomaly struct {
+	Timestamp time.Time `json:"timestamp"`
+	SeriesKey string    `json:"series_key"`
+	Observed  float64   `json:"observed"`
+	Expected  float64   `json:"expected"`
+	Score     float64   `json:"score"`
+}
+
+// AnomaliesService runs a rolling median-absolute-deviation outlier
+// detector over CostSummary history, one series per (provider), (model),
+// and (project) dimension found in CostSummary.ByProvider/ByModel/ByProject,
+// and emits EventUsageAnomalyDetected for every detection via the webhook
+// subsystem. Like BudgetForecaster, detection runs client-side against
+// history the caller fetches (e.g. one CostService.Summary call per
+// bucket); it keeps no server-side state of its own.
+type AnomaliesService struct {
+	client *Client
+
+	mu       sync.Mutex
+	detected []Anomaly
+}
+
+// seriesPoint is a single bucket's value within a cost series.
+type seriesPoint struct {
+	timestamp time.Time
+	value     float64
+}
+
+// Detect runs the detector over history, oldest bucket first, and returns
+// every anomaly it flags across all series. cfg may be nil to use
+// DefaultAnomalyWindowSize/DefaultAnomalyZThreshold. Detections are
+// recorded for List and emitted as EventUsageAnomalyDetected events.
+func (s *AnomaliesService) Detect(ctx context.Context, history []CostSummary, cfg *AnomalyDetectorConfig) ([]Anomaly, error) {
+	if len(history) == 0 {
+		return nil, fmt.Errorf("%w: history must have at least one bucket", ErrBadRequest)
+	}
+
+	series, err := buildCostSeries(history)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := cfg.withDefaults()
+
+	keys := make([]string, 0, len(series))
+	for key := range series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var anomalies []Anomaly
+	for _, key := range keys {
+		anomalies = append(anomalies, detectSeriesAnomalies(key, series[key], resolved)...)
+	}
+	sort.Slice(anomalies, func(i, j int) bool {
+		if anomalies[i].Timestamp.Equal(anomalies[j].Timestamp) {
+			return anomalies[i].SeriesKey < anomalies[j].SeriesKey
+		}
+		return anomalies[i].Timestamp.Before(anomalies[j].Timestamp)
+	})
+
+	s.mu.Lock()
+	s.detected = append(s.detected, anomalies...)
+	s.mu.Unlock()
+
+	for _, a := range anomalies {
+		s.client.Webhooks.emit(EventUsageAnomalyDetected, a)
+	}
+
+	return anomalies, nil
+}
+
+// List returns previously Detect-ed anomalies whose Timestamp falls within
+// period (a zero Start or End leaves that bound open) and whose SeriesKey
+// matches filters. A zero Filters matches every series.
+func (s *AnomaliesService) List(ctx context.Context, period Period, filters Filters) ([]Anomaly, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []Anomaly
+	for _, a := range s.detected {
+		if !period.Start.IsZero() && a.Timestamp.Before(period.Start) {
+			continue
+		}
+		if !period.End.IsZero() && a.Timestamp.After(period.End) {
+			continue
+		}
+		if !anomalyMatchesFilters(a, filters) {
+			continue
+		}
+		result = append(result, a)
+	}
+	return result, nil
+}
+
+// anomalyMatchesFilters reports whether a's SeriesKey belongs to one of the
+// dimensions filters names. Dimensions filters leaves empty are not
+// restricted.
+func anomalyMatchesFilters(a Anomaly, filters Filters) bool {
+	if len(filters.Providers) > 0 {
+		matched := false
+		for _, p := range filters.Providers {
+			if a.SeriesKey == "provider:"+string(p) {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	if len(filters.Models) > 0 {
+		for _, m := range filters.Models {
+			if a.SeriesKey == "model:"+m {
+				return true
+			}
+		}
+	}
+	if filters.ProjectID != "" && a.SeriesKey == "project:"+filters.ProjectID {
+		return true
+	}
+	return len(filters.Providers) == 0 && len(filters.Models) == 0 && filters.ProjectID == ""
+}
+
+// buildCostSeries flattens history's ByProvider/ByModel/ByProject maps into
+// one time-ordered series per dimension value, keyed "provider:<p>",
+// "model:<m>", or "project:<id>".
+func buildCostSeries(history []CostSummary) (map[string][]seriesPoint, error) {
+	series := make(map[string][]seriesPoint)
+
+	appendPoint := func(key string, ts time.Time, raw string) error {
+		if raw == "" {
+			return nil
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%w: parsing series %q value %q: %v", ErrServerError, key, raw, err)
+		}
+		series[key] = append(series[key], seriesPoint{timestamp: ts, value: v})
+		return nil
+	}
+
+	for _, bucket := range history {
+		ts := bucket.Period.Start
+		for provider, cost := range bucket.ByProvider {
+			if err := appendPoint("provider:"+string(provider), ts, cost); err != nil {
+				return nil, err
+			}
+		}
+		for model, cost := range bucket.ByModel {
+			if err := appendPoint("model:"+model, ts, cost); err != nil {
+				return nil, err
+			}
+		}
+		for project, cost := range bucket.ByProject {
+			if err := appendPoint("project:"+project, ts, cost); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return series, nil
+}
+
+// detectSeriesAnomalies runs the iterative MAD detector over a single
+// series: compute the median M and MAD over the trailing WindowSize
+// points, flag the point with the largest modified z-score if it exceeds
+// ZThreshold, remove it, and repeat — so one huge spike doesn't inflate the
+// MAD enough to mask smaller outliers behind it.
+func detectSeriesAnomalies(key string, points []seriesPoint, cfg AnomalyDetectorConfig) []Anomaly {
+	start := 0
+	if len(points) > cfg.WindowSize {
+		start = len(points) - cfg.WindowSize
+	}
+	active := append([]seriesPoint(nil), points[start:]...)
+
+	maxOutliers := cfg.MaxOutliers
+	if maxOutliers <= 0 || maxOutliers > len(active) {
+		maxOutliers = len(active)
+	}
+
+	var anomalies []Anomaly
+	for i := 0; i < maxOutliers && len(active) > 1; i++ {
+		values := make([]float64, len(active))
+		for j, p := range active {
+			values[j] = p.value
+		}
+		med := median(values)
+		mad := medianAbsoluteDeviation(values, med)
+
+		worst := -1
+		worstScore := cfg.ZThreshold
+		for j, p := range active {
+			score := modifiedZScore(p.value, med, mad)
+			if score > worstScore {
+				worstScore = score
+				worst = j
+			}
+		}
+		if worst < 0 {
+			break
+		}
+
+		flagged := active[worst]
+		anomalies = append(anomalies, Anomaly{
+			Timestamp: flagged.timestamp,
+			SeriesKey: key,
+			Observed:  flagged.value,
+			Expected:  med,
+			Score:     worstScore,
+		})
+		active = append(active[:worst], active[worst+1:]...)
+	}
+
+	return anomalies
+}
+
+// modifiedZScore is |x - median| / (1.4826 * MAD). When mad is zero (every
+// remaining point is identical), x is only flagged if it differs at all.
+func modifiedZScore(x, med, mad float64) float64 {
+	if mad == 0 {
+		if x == med {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return math.Abs(x-med) / (madConsistencyConstant * mad)
+}
+
+// median returns the median of values. values is not mutated.
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// medianAbsoluteDeviation returns the median of |values[i] - med|.
+func medianAbsoluteDeviation(values []float64, med float64) float64 {
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - med)
+	}
+	return median(deviations)
+}
@@ -0,0 +1,105 @@
+package llmcostops
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func costSummaryBucket(day int, byProvider map[Provider]string) CostSummary {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, day)
+	return CostSummary{
+		Period:     Period{Start: start, End: start.Add(24 * time.Hour)},
+		ByProvider: byProvider,
+	}
+}
+
+func TestAnomaliesService_Detect_FlagsSpike(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	var history []CostSummary
+	for day := 0; day < 10; day++ {
+		history = append(history, costSummaryBucket(day, map[Provider]string{ProviderOpenAI: "100"}))
+	}
+	// One huge spike in an otherwise flat series.
+	history[5] = costSummaryBucket(5, map[Provider]string{ProviderOpenAI: "5000"})
+
+	got, err := client.Anomalies.Detect(context.Background(), history, nil)
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("len(got) = %d, want 1", len(got))
+	}
+	if got[0].SeriesKey != "provider:openai" {
+		t.Errorf("SeriesKey = %q, want provider:openai", got[0].SeriesKey)
+	}
+	if got[0].Observed != 5000 {
+		t.Errorf("Observed = %v, want 5000", got[0].Observed)
+	}
+}
+
+func TestAnomaliesService_Detect_FlatSeriesHasNoAnomalies(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	var history []CostSummary
+	for day := 0; day < 10; day++ {
+		history = append(history, costSummaryBucket(day, map[Provider]string{ProviderOpenAI: "100"}))
+	}
+
+	got, err := client.Anomalies.Detect(context.Background(), history, nil)
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("len(got) = %d, want 0", len(got))
+	}
+}
+
+func TestAnomaliesService_Detect_RejectsEmptyHistory(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	if _, err := client.Anomalies.Detect(context.Background(), nil, nil); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestAnomaliesService_List_FiltersByPeriodAndProvider(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	var history []CostSummary
+	for day := 0; day < 10; day++ {
+		history = append(history, costSummaryBucket(day, map[Provider]string{
+			ProviderOpenAI:    "100",
+			ProviderAnthropic: "100",
+		}))
+	}
+	history[5] = costSummaryBucket(5, map[Provider]string{
+		ProviderOpenAI:    "5000",
+		ProviderAnthropic: "100",
+	})
+
+	if _, err := client.Anomalies.Detect(context.Background(), history, nil); err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	got, err := client.Anomalies.List(context.Background(), Period{}, Filters{Providers: []Provider{ProviderOpenAI}})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(got) != 1 || got[0].SeriesKey != "provider:openai" {
+		t.Fatalf("List() = %+v, want one provider:openai anomaly", got)
+	}
+
+	got, err = client.Anomalies.List(context.Background(), Period{Start: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)}, Filters{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("List() with future Start = %+v, want none", got)
+	}
+}
@@ -0,0 +1,50 @@
+// Package auth provides pluggable request authentication for the LLM Cost
+// Ops SDK client, beyond the static Bearer token the client used
+// exclusively before. Implementations are wired in via
+// llmcostops.WithAuthenticator.
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Authenticator applies credentials to an outgoing request and, for
+// schemes that support it, refreshes those credentials on demand (e.g.
+// after a 401).
+type Authenticator interface {
+	// Apply sets whatever headers (or other request state) are needed to
+	// authenticate req.
+	Apply(ctx context.Context, req *http.Request) error
+
+	// Refresh re-derives credentials, e.g. exchanging a refresh token for a
+	// new access token. Authenticators that don't support refresh (such as
+	// a static API key) should return a nil error and do nothing.
+	Refresh(ctx context.Context) error
+}
+
+// APIKey is a static Bearer-token Authenticator — the SDK's original and
+// still-default authentication scheme.
+type APIKey struct {
+	Key string
+}
+
+// NewAPIKey constructs a static API key Authenticator.
+func NewAPIKey(key string) *APIKey {
+	return &APIKey{Key: key}
+}
+
+// Apply implements Authenticator.
+func (a *APIKey) Apply(_ context.Context, req *http.Request) error {
+	if a.Key == "" {
+		return fmt.Errorf("auth: API key is empty")
+	}
+	req.Header.Set("Authorization", "Bearer "+a.Key)
+	return nil
+}
+
+// Refresh implements Authenticator. A static key never needs refreshing.
+func (a *APIKey) Refresh(context.Context) error {
+	return nil
+}
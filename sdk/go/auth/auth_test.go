@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAPIKey_Apply(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{name: "valid key", key: "test-key"},
+		{name: "empty key", key: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewAPIKey(tt.key)
+			req := httptest.NewRequest(http.MethodGet, "https://example.com/api/v1/usage", nil)
+
+			err := a.Apply(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := req.Header.Get("Authorization"); got != "Bearer "+tt.key {
+				t.Errorf("Authorization header = %q, want %q", got, "Bearer "+tt.key)
+			}
+		})
+	}
+}
+
+func TestAPIKey_Refresh(t *testing.T) {
+	a := NewAPIKey("test-key")
+	if err := a.Refresh(context.Background()); err != nil {
+		t.Errorf("Refresh() = %v, want nil", err)
+	}
+}
+
+func TestHMACKeyEmail_Apply(t *testing.T) {
+	a := NewHMACKeyEmail("user@example.com", "secret")
+	a.Now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	req := httptest.NewRequest(http.MethodPost, "https://example.com/api/v1/usage", nil)
+	if err := a.Apply(context.Background(), req); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	if got := req.Header.Get("X-Auth-Email"); got != "user@example.com" {
+		t.Errorf("X-Auth-Email = %q, want %q", got, "user@example.com")
+	}
+	if got := req.Header.Get("X-Auth-Timestamp"); got != "1700000000" {
+		t.Errorf("X-Auth-Timestamp = %q, want %q", got, "1700000000")
+	}
+	if got := req.Header.Get("X-Auth-Signature"); len(got) != 64 || strings.Contains(got, " ") {
+		t.Errorf("X-Auth-Signature = %q, want 64-char hex digest", got)
+	}
+}
+
+func TestMTLS_TLSConfig(t *testing.T) {
+	a := NewMTLS(nil)
+	if got := a.TLSConfig(); got != nil {
+		t.Errorf("TLSConfig() = %v, want nil", got)
+	}
+}
@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+)
+
+// MTLS authenticates via client-certificate mutual TLS, delegating to a
+// caller-supplied *tls.Config installed on the HTTP client's transport.
+// Apply is a no-op since the credential lives at the transport layer, not
+// the request; llmcostops.NewClient installs TLSConfig() onto the
+// http.Transport it builds when an MTLS authenticator is supplied. A client
+// passed via WithHTTPClient is used as is and does not get TLSConfig().
+type MTLS struct {
+	tlsConfig *tls.Config
+}
+
+// NewMTLS constructs an MTLS authenticator from a pre-built *tls.Config
+// (typically carrying a client certificate and the server's CA pool).
+func NewMTLS(tlsConfig *tls.Config) *MTLS {
+	return &MTLS{tlsConfig: tlsConfig}
+}
+
+// TLSConfig returns the *tls.Config to install on the HTTP transport.
+func (a *MTLS) TLSConfig() *tls.Config {
+	return a.tlsConfig
+}
+
+// Apply implements Authenticator. mTLS credentials are presented during the
+// TLS handshake, not per-request, so this is a no-op.
+func (a *MTLS) Apply(context.Context, *http.Request) error {
+	return nil
+}
+
+// Refresh implements Authenticator. Certificate rotation is the caller's
+// responsibility (rebuild and swap the *tls.Config); there's nothing this
+// Authenticator can refresh on its own.
+func (a *MTLS) Refresh(context.Context) error {
+	return nil
+}
@@ -0,0 +1,93 @@
+package llmcostops
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// PreparedRequest is a single unit of work for a BatchClient: a closure
+// over whatever Client/service call the caller wants to run, returning its
+// typed result as interface{}. Closing over client.Usage.Get, client.Costs.List,
+// etc. means a batch runs through the exact same rate limiter, retry
+// policy, and middleware chain as any other call — BatchClient only adds
+// bounded fan-out and ordered results on top.
+type PreparedRequest func(ctx context.Context) (interface{}, error)
+
+// BatchResult is the outcome of one PreparedRequest, at the same index it
+// was submitted.
+type BatchResult struct {
+	Value interface{}
+	Err   error
+}
+
+// DefaultBatchWorkers is the worker pool size BatchClient uses when none is
+// configured.
+const DefaultBatchWorkers = 8
+
+// BatchClient runs a slice of PreparedRequests through a bounded worker
+// pool, for callers bulk-fetching or bulk-submitting many records without
+// hand-rolling their own fan-out over Usage/Costs/Pricing calls.
+type BatchClient struct {
+	client  *Client
+	workers int
+}
+
+// NewBatchClient returns a BatchClient bound to client, running up to
+// workers requests concurrently. workers <= 0 uses DefaultBatchWorkers.
+func NewBatchClient(client *Client, workers int) *BatchClient {
+	if workers <= 0 {
+		workers = DefaultBatchWorkers
+	}
+	return &BatchClient{client: client, workers: workers}
+}
+
+// Execute runs reqs through the worker pool and returns one BatchResult per
+// request, in the same order as reqs. A request's failure does not cancel
+// the others; it is recorded in that request's BatchResult.Err. Execute
+// itself only returns an error if ctx is canceled before every request has
+// been dispatched.
+func (b *BatchClient) Execute(ctx context.Context, reqs []PreparedRequest) ([]BatchResult, error) {
+	results := make([]BatchResult, len(reqs))
+	if len(reqs) == 0 {
+		return results, nil
+	}
+
+	type job struct {
+		index int
+		req   PreparedRequest
+	}
+
+	jobs := make(chan job)
+	var wg sync.WaitGroup
+
+	workers := b.workers
+	if workers > len(reqs) {
+		workers = len(reqs)
+	}
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				v, err := j.req(ctx)
+				results[j.index] = BatchResult{Value: v, Err: err}
+			}
+		}()
+	}
+
+	for i, req := range reqs {
+		select {
+		case jobs <- job{index: i, req: req}:
+		case <-ctx.Done():
+			close(jobs)
+			wg.Wait()
+			return results, fmt.Errorf("%w: %v", ErrContextCanceled, ctx.Err())
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results, nil
+}
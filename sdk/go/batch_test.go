@@ -0,0 +1,132 @@
+package llmcostops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatchClient_Execute_OrdersResultsByIndex(t *testing.T) {
+	var inFlight, maxObserved int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&inFlight, 1)
+		for {
+			old := atomic.LoadInt64(&maxObserved)
+			if n <= old || atomic.CompareAndSwapInt64(&maxObserved, old, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt64(&inFlight, -1)
+
+		id := strings.TrimPrefix(r.URL.Path, "/api/v1/usage/")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(UsageRecord{ID: id})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	batch := NewBatchClient(client, 2)
+
+	const n = 6
+	reqs := make([]PreparedRequest, n)
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("usage-%d", i)
+		reqs[i] = func(ctx context.Context) (interface{}, error) {
+			return client.Usage.Get(ctx, id)
+		}
+	}
+
+	results, err := batch.Execute(context.Background(), reqs)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(results) != n {
+		t.Fatalf("got %d results, want %d", len(results), n)
+	}
+
+	for i, r := range results {
+		if r.Err != nil {
+			t.Errorf("result[%d].Err = %v, want nil", i, r.Err)
+			continue
+		}
+		rec, ok := r.Value.(*UsageRecord)
+		if !ok {
+			t.Fatalf("result[%d].Value has type %T, want *UsageRecord", i, r.Value)
+		}
+		if want := fmt.Sprintf("usage-%d", i); rec.ID != want {
+			t.Errorf("result[%d].Value.ID = %q, want %q", i, rec.ID, want)
+		}
+	}
+
+	if got := atomic.LoadInt64(&maxObserved); got > 2 {
+		t.Errorf("max concurrent requests = %d, want <= 2 (BatchClient workers=2)", got)
+	}
+}
+
+func TestBatchClient_Execute_PerRequestErrorsDontCancelOthers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(UsageRecord{ID: "ok"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	batch := NewBatchClient(client, 4)
+	reqs := []PreparedRequest{
+		func(ctx context.Context) (interface{}, error) { return client.Usage.Get(ctx, "ok-1") },
+		func(ctx context.Context) (interface{}, error) { return client.Usage.Get(ctx, "missing") },
+		func(ctx context.Context) (interface{}, error) { return client.Usage.Get(ctx, "ok-2") },
+	}
+
+	results, err := batch.Execute(context.Background(), reqs)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if results[0].Err != nil || results[2].Err != nil {
+		t.Errorf("expected successful requests to succeed, got errs %v, %v", results[0].Err, results[2].Err)
+	}
+	if !errors.Is(results[1].Err, ErrNotFound) {
+		t.Errorf("results[1].Err = %v, want ErrNotFound", results[1].Err)
+	}
+}
+
+func TestBatchClient_Execute_Empty(t *testing.T) {
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL("https://example.invalid"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	batch := NewBatchClient(client, 4)
+	results, err := batch.Execute(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("got %d results, want 0", len(results))
+	}
+}
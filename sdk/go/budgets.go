@@ -0,0 +1,206 @@
+package llmcostops
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// BudgetScope is the level of a Budget within the org → project → user →
+// tag hierarchy BudgetsService manages.
+type BudgetScope string
+
+const (
+	BudgetScopeOrganization BudgetScope = "organization"
+	BudgetScopeProject      BudgetScope = "project"
+	BudgetScopeUser         BudgetScope = "user"
+	BudgetScopeTag          BudgetScope = "tag"
+)
+
+// Budget is a spend limit scoped to one level of the org → project →
+// user → tag hierarchy, with soft and hard thresholds expressed as a
+// fraction of Limit (e.g. 0.5, 0.8, 1.0). ParentID, if set, names the
+// Budget one level up whose spend this Budget narrows; a
+// BudgetScopeOrganization Budget has no parent.
+type Budget struct {
+	ID            string       `json:"id,omitempty"`
+	Name          string       `json:"name"`
+	Scope         BudgetScope  `json:"scope"`
+	ParentID      string       `json:"parent_id,omitempty"`
+	Filters       Filters      `json:"filters"`
+	Limit         float64      `json:"limit"`
+	LimitUnit     string       `json:"limit_unit"` // "usd" or "tokens"
+	Window        BudgetWindow `json:"window"`
+	SoftThreshold float64      `json:"soft_threshold"`
+	HardThreshold float64      `json:"hard_threshold"`
+	Notifiers     []Notifier   `json:"notifiers,omitempty"`
+	Enabled       bool         `json:"enabled"`
+}
+
+// asRule adapts b to a BudgetRule so it can be projected by
+// BudgetForecaster, which forecasts in BudgetRule terms regardless of
+// where in the hierarchy the budget being forecast sits.
+func (b Budget) asRule() BudgetRule {
+	return BudgetRule{
+		ID:            b.ID,
+		Name:          b.Name,
+		Filters:       b.Filters,
+		Limit:         b.Limit,
+		LimitUnit:     b.LimitUnit,
+		Window:        b.Window,
+		SoftThreshold: b.SoftThreshold,
+		HardThreshold: b.HardThreshold,
+		Notifiers:     b.Notifiers,
+		Enabled:       b.Enabled,
+	}
+}
+
+// BudgetsService manages hierarchical budgets: an organization-level
+// Budget can have project-level children, each project can have
+// user-level children, and each user can have tag-level children, so
+// spend limits narrow as they're defined at more specific scopes. Pair
+// it with AnomaliesService, which catches spikes a budget's forecast
+// alone wouldn't flag until its window closes.
+type BudgetsService struct {
+	client *Client
+}
+
+// Create creates a new budget at any level of the hierarchy. A
+// BudgetScopeOrganization budget must leave ParentID empty; every other
+// scope requires one, naming the Budget ID one level up.
+func (s *BudgetsService) Create(ctx context.Context, budget *Budget) (*Budget, error) {
+	if budget == nil || budget.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrBadRequest)
+	}
+	if budget.Scope == "" {
+		return nil, fmt.Errorf("%w: scope is required", ErrBadRequest)
+	}
+	if budget.Scope != BudgetScopeOrganization && budget.ParentID == "" {
+		return nil, fmt.Errorf("%w: parent_id is required for scope %q", ErrBadRequest, budget.Scope)
+	}
+
+	req, err := s.client.newRequest(http.MethodPost, "/api/v1/budgets", budget)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Budget
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Update updates an existing budget.
+func (s *BudgetsService) Update(ctx context.Context, id string, budget *Budget) (*Budget, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: id is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/budgets/%s", url.PathEscape(id))
+	req, err := s.client.newRequest(http.MethodPut, path, budget)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Budget
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Delete deletes a budget by ID. Whether deleting a budget that still has
+// children is rejected or cascades is the server's call, not the SDK's.
+func (s *BudgetsService) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: id is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/budgets/%s", url.PathEscape(id))
+	req, err := s.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	return s.client.do(ctx, req, nil)
+}
+
+// Get retrieves a budget by ID.
+func (s *BudgetsService) Get(ctx context.Context, id string) (*Budget, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: id is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/budgets/%s", url.PathEscape(id))
+	req, err := s.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Budget
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// List lists every budget across the hierarchy. Use Children to walk it
+// one level at a time instead.
+func (s *BudgetsService) List(ctx context.Context) ([]Budget, error) {
+	req, err := s.client.newRequest(http.MethodGet, "/api/v1/budgets", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Budget
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Children lists the budgets one level down the hierarchy from
+// parentID (e.g. the project-level budgets under an organization
+// budget). Pass "" to list root, organization-level budgets.
+func (s *BudgetsService) Children(ctx context.Context, parentID string) ([]Budget, error) {
+	req, err := s.client.newRequest(http.MethodGet, "/api/v1/budgets", nil)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Set("parent_id", parentID)
+	req.URL.RawQuery = q.Encode()
+
+	var result []Budget
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Forecast projects budgetID's spend through the end of its window using
+// a BudgetForecaster built with DefaultForecastAlpha over history — one
+// CostSummary per elapsed bucket of the budget's Window so far, oldest
+// first, scoped to the budget's Filters by the caller (e.g. via
+// CostService.Summary called once per bucket, rolled up from its
+// ByProject/ByModel series). When it projects a breach of SoftThreshold
+// or HardThreshold it emits EventBudgetThresholdCrossed, the same event
+// RulesService.ForecastBudgetRule emits for a flat BudgetRule.
+func (s *BudgetsService) Forecast(ctx context.Context, budgetID string, history []CostSummary) (*BudgetForecast, error) {
+	budget, err := s.Get(ctx, budgetID)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast, err := NewBudgetForecaster().Forecast(budget.asRule(), history)
+	if err != nil {
+		return nil, err
+	}
+
+	if forecast.WillBreachSoft || forecast.WillBreachHard {
+		s.client.Webhooks.emit(EventBudgetThresholdCrossed, forecast)
+	}
+
+	return forecast, nil
+}
@@ -0,0 +1,86 @@
+package llmcostops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBudgetsService_Create_RequiresParentForNonRootScope(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	_, err := client.Budgets.Create(context.Background(), &Budget{
+		Name:  "team-a",
+		Scope: BudgetScopeProject,
+	})
+	if err == nil {
+		t.Fatal("Create() error = nil, want an error for missing parent_id")
+	}
+}
+
+func TestBudgetsService_Children_ScopesToParent(t *testing.T) {
+	var gotParentID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotParentID = r.URL.Query().Get("parent_id")
+		json.NewEncoder(w).Encode([]Budget{
+			{ID: "budget-project-a", Name: "project-a", Scope: BudgetScopeProject, ParentID: gotParentID},
+		})
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	children, err := client.Budgets.Children(context.Background(), "budget-org-1")
+	if err != nil {
+		t.Fatalf("Children() error = %v", err)
+	}
+	if gotParentID != "budget-org-1" {
+		t.Errorf("parent_id query = %q, want budget-org-1", gotParentID)
+	}
+	if len(children) != 1 || children[0].ParentID != "budget-org-1" {
+		t.Errorf("Children() = %+v", children)
+	}
+}
+
+func TestBudgetsService_Forecast_EmitsWebhookOnBreach(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Budget{
+			ID:            "budget-1",
+			Name:          "org-wide",
+			Scope:         BudgetScopeOrganization,
+			Limit:         1000,
+			Window:        BudgetWindowMonthly,
+			SoftThreshold: 0.5,
+			HardThreshold: 1.0,
+		})
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	fired := make(chan struct{}, 1)
+	client.OnEvent(EventBudgetThresholdCrossed, func(event WebhookEvent) {
+		fired <- struct{}{}
+	})
+
+	forecast, err := client.Budgets.Forecast(context.Background(), "budget-1", []CostSummary{
+		{Period: Period{}, TotalCost: "600"},
+	})
+	if err != nil {
+		t.Fatalf("Forecast() error = %v", err)
+	}
+	if !forecast.WillBreachSoft {
+		t.Errorf("WillBreachSoft = false, want true for observed spend over half the limit")
+	}
+
+	select {
+	case <-fired:
+	default:
+		t.Error("EventBudgetThresholdCrossed was not emitted")
+	}
+}
@@ -0,0 +1,233 @@
+package llmcostops
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// errNotModified is checkResponseStatus's sentinel for a 304 response to
+// a conditional (If-None-Match/If-Modified-Since) request. It isn't a
+// failure; doCached uses it to decide whether to keep serving the cached
+// body.
+var errNotModified = errors.New("not modified")
+
+// cacheablePathPrefixes lists the endpoints response caching applies to:
+// Pricing/Usage/Costs GETs, which are largely idempotent and, in the
+// case of pricing tables, rarely change.
+var cacheablePathPrefixes = []string{
+	"/api/v1/pricing",
+	"/api/v1/usage",
+	"/api/v1/costs",
+}
+
+func isCacheablePath(path string) bool {
+	for _, prefix := range cacheablePathPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func cacheKey(req *http.Request) string {
+	return req.Method + " " + req.URL.String()
+}
+
+// doCached serves req from c.cache when possible, falling back to
+// c.doWithMeta (and populating the cache from its result) on a miss, an
+// expired entry without stale-while-revalidate, or a conditional
+// revalidation.
+func (c *Client) doCached(ctx context.Context, req *http.Request, v interface{}) error {
+	key := cacheKey(req)
+	cached, meta, ok := c.cache.Get(key)
+
+	if ok {
+		if time.Since(meta.StoredAt) < c.cacheTTL {
+			return json.Unmarshal(cached, v)
+		}
+
+		if c.staleWhileRevalidate {
+			go c.revalidateCache(req.Clone(context.Background()), key, cached, meta)
+			return json.Unmarshal(cached, v)
+		}
+
+		if meta.ETag != "" {
+			req.Header.Set("If-None-Match", meta.ETag)
+		}
+		if meta.LastModified != "" {
+			req.Header.Set("If-Modified-Since", meta.LastModified)
+		}
+	}
+
+	respMeta, err := c.doWithMeta(ctx, req, v)
+	if err != nil {
+		if errors.Is(err, errNotModified) {
+			c.cache.Set(key, cached, Metadata{ETag: respMeta.ETag, LastModified: respMeta.LastModified, StoredAt: time.Now()})
+			return json.Unmarshal(cached, v)
+		}
+		return err
+	}
+
+	if respMeta.ETag != "" || respMeta.LastModified != "" {
+		c.cache.Set(key, respMeta.Body, Metadata{ETag: respMeta.ETag, LastModified: respMeta.LastModified, StoredAt: time.Now()})
+	}
+	return nil
+}
+
+// revalidateCache re-requests a stale cache entry in the background for
+// stale-while-revalidate mode, refreshing it on success or on 304 and
+// logging (but not propagating) a failure.
+func (c *Client) revalidateCache(req *http.Request, key string, cachedBody []byte, meta Metadata) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.httpClient.Timeout)
+	defer cancel()
+
+	if meta.ETag != "" {
+		req.Header.Set("If-None-Match", meta.ETag)
+	}
+	if meta.LastModified != "" {
+		req.Header.Set("If-Modified-Since", meta.LastModified)
+	}
+
+	respMeta, err := c.doWithMeta(ctx, req, nil)
+	switch {
+	case err == nil:
+		c.cache.Set(key, respMeta.Body, Metadata{ETag: respMeta.ETag, LastModified: respMeta.LastModified, StoredAt: time.Now()})
+	case errors.Is(err, errNotModified):
+		c.cache.Set(key, cachedBody, Metadata{ETag: respMeta.ETag, LastModified: respMeta.LastModified, StoredAt: time.Now()})
+	default:
+		c.logger.Warn("background cache revalidation failed", zap.String("cache_key", key), zap.Error(err))
+	}
+}
+
+// DefaultCacheTTL is how long a cached entry is considered fresh when no
+// explicit WithCacheTTL is supplied.
+const DefaultCacheTTL = 5 * time.Minute
+
+// Metadata accompanies a cached response body with the information needed
+// to revalidate it: the validators the server sent (ETag/Last-Modified)
+// and when the entry was stored.
+type Metadata struct {
+	ETag         string
+	LastModified string
+	StoredAt     time.Time
+}
+
+// Cache stores raw API response bodies keyed by "<method> <url>", so
+// repeat GETs to largely-idempotent endpoints (pricing tables in
+// particular rarely change) can be served without a round trip.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the cached body and its Metadata, or ok=false on a miss.
+	Get(key string) (body []byte, meta Metadata, ok bool)
+
+	// Set stores body and its Metadata under key, evicting older entries
+	// if the implementation is capacity-bounded.
+	Set(key string, body []byte, meta Metadata)
+}
+
+// WithCache enables response caching for GET requests to the
+// Pricing/Usage/Costs endpoints, using cache to store and retrieve
+// entries. Combine with WithCacheTTL and WithStaleWhileRevalidate to
+// control freshness behavior.
+func WithCache(cache Cache) Option {
+	return func(c *Config) error {
+		c.Cache = cache
+		return nil
+	}
+}
+
+// WithCacheTTL overrides how long a cached entry is considered fresh
+// before it must be revalidated (or, in stale-while-revalidate mode,
+// served stale while a refresh happens in the background). Defaults to
+// DefaultCacheTTL.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(c *Config) error {
+		c.CacheTTL = ttl
+		return nil
+	}
+}
+
+// WithStaleWhileRevalidate makes expired cache entries serve immediately
+// while a background goroutine revalidates them against the server,
+// instead of blocking the caller on a conditional request.
+func WithStaleWhileRevalidate() Option {
+	return func(c *Config) error {
+		c.StaleWhileRevalidate = true
+		return nil
+	}
+}
+
+// MemoryCache is an in-memory, capacity-bounded Cache evicting the least
+// recently used entry once full.
+type MemoryCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	ll      *list.List
+	entries map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key  string
+	body []byte
+	meta Metadata
+}
+
+// NewMemoryCache constructs an in-memory LRU Cache holding at most
+// maxEntries entries. maxEntries <= 0 means unbounded.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (c *MemoryCache) Get(key string) ([]byte, Metadata, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, Metadata{}, false
+	}
+	c.ll.MoveToFront(el)
+	entry := el.Value.(*memoryCacheEntry)
+	return entry.body, entry.meta, true
+}
+
+// Set implements Cache.
+func (c *MemoryCache) Set(key string, body []byte, meta Metadata) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.ll.MoveToFront(el)
+		el.Value.(*memoryCacheEntry).body = body
+		el.Value.(*memoryCacheEntry).meta = meta
+		return
+	}
+
+	el := c.ll.PushFront(&memoryCacheEntry{key: key, body: body, meta: meta})
+	c.entries[key] = el
+
+	if c.maxEntries > 0 {
+		for c.ll.Len() > c.maxEntries {
+			oldest := c.ll.Back()
+			if oldest == nil {
+				break
+			}
+			c.ll.Remove(oldest)
+			delete(c.entries, oldest.Value.(*memoryCacheEntry).key)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+//go:build rediscache
+
+package llmcostops
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache is a Cache backed by Redis, so response caching can be
+// shared across multiple SDK instances/processes instead of staying
+// local to one. Build with -tags rediscache to include it.
+type RedisCache struct {
+	rdb    *redis.Client
+	prefix string
+}
+
+type redisCacheEntry struct {
+	Body []byte   `json:"body"`
+	Meta Metadata `json:"meta"`
+}
+
+// NewRedisCache wraps an existing *redis.Client as a Cache, namespacing
+// keys under prefix (e.g. "costops:cache:") to avoid colliding with
+// other uses of the same Redis instance.
+func NewRedisCache(rdb *redis.Client, prefix string) *RedisCache {
+	return &RedisCache{rdb: rdb, prefix: prefix}
+}
+
+// Get implements Cache.
+func (c *RedisCache) Get(key string) ([]byte, Metadata, bool) {
+	raw, err := c.rdb.Get(context.Background(), c.prefix+key).Bytes()
+	if err != nil {
+		return nil, Metadata{}, false
+	}
+
+	var entry redisCacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, Metadata{}, false
+	}
+	return entry.Body, entry.Meta, true
+}
+
+// Set implements Cache.
+func (c *RedisCache) Set(key string, body []byte, meta Metadata) {
+	raw, err := json.Marshal(redisCacheEntry{Body: body, Meta: meta})
+	if err != nil {
+		return
+	}
+	// No expiry: freshness is judged client-side against Metadata.StoredAt
+	// and the client's configured cache TTL, the same as MemoryCache.
+	c.rdb.Set(context.Background(), c.prefix+key, raw, 0)
+}
@@ -0,0 +1,89 @@
+package llmcostops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache_GetSet(t *testing.T) {
+	c := NewMemoryCache(2)
+
+	if _, _, ok := c.Get("missing"); ok {
+		t.Fatal("expected miss on empty cache")
+	}
+
+	c.Set("a", []byte(`{"id":"a"}`), Metadata{ETag: `"a"`, StoredAt: time.Now()})
+	body, meta, ok := c.Get("a")
+	if !ok {
+		t.Fatal("expected hit after Set")
+	}
+	if string(body) != `{"id":"a"}` || meta.ETag != `"a"` {
+		t.Errorf("Get() = %s, %+v, want matching body/etag", body, meta)
+	}
+}
+
+func TestMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewMemoryCache(2)
+	c.Set("a", []byte("a"), Metadata{})
+	c.Set("b", []byte("b"), Metadata{})
+	c.Get("a") // touch a, making b the LRU entry
+	c.Set("c", []byte("c"), Metadata{})
+
+	if _, _, ok := c.Get("b"); ok {
+		t.Error("expected b to be evicted as the least recently used entry")
+	}
+	if _, _, ok := c.Get("a"); !ok {
+		t.Error("expected a to survive eviction")
+	}
+	if _, _, ok := c.Get("c"); !ok {
+		t.Error("expected c to survive as the newest entry")
+	}
+}
+
+func TestClient_CacheRevalidatesOnETag(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(&PricingTable{ID: "p1", Provider: ProviderOpenAI})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithCache(NewMemoryCache(16)),
+		WithCacheTTL(time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	if _, err := client.Pricing.Get(ctx, "p1"); err != nil {
+		t.Fatalf("first Get() error = %v", err)
+	}
+
+	time.Sleep(2 * time.Millisecond) // let the entry go stale
+
+	got, err := client.Pricing.Get(ctx, "p1")
+	if err != nil {
+		t.Fatalf("second Get() error = %v", err)
+	}
+	if got.ID != "p1" {
+		t.Errorf("Get() = %+v, want the cached entry served back on 304", got)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2 (initial fetch + revalidation)", requests)
+	}
+}
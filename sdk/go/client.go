@@ -32,8 +32,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"golang.org/x/time/rate"
+
+	"github.com/llm-devops/llm-cost-ops/sdk/go/auth"
 )
 
 const (
@@ -84,28 +87,61 @@ var (
 // It is safe for concurrent use by multiple goroutines.
 type Client struct {
 	// Configuration
-	baseURL    *url.URL
-	apiKey     string
-	httpClient *http.Client
-	logger     *zap.Logger
+	baseURL       *url.URL
+	authenticator auth.Authenticator
+	httpClient    *http.Client
+	logger        *zap.Logger
 
 	// Rate limiting
 	rateLimiter *rate.Limiter
 
 	// Retry configuration
-	maxRetries int
-	retryDelay time.Duration
+	maxRetries  int
+	retryDelay  time.Duration
+	retryPolicy RetryPolicy
 
 	// Service clients
-	Pricing *PricingService
-	Usage   *UsageService
-	Costs   *CostService
-	Export  *ExportService
-	Health  *HealthService
+	Pricing   *PricingService
+	Usage     *UsageService
+	Costs     *CostService
+	Export    *ExportService
+	Health    *HealthService
+	Alerts    *AlertService
+	Rules     *RulesService
+	Metrics   *MetricsService
+	Webhooks  *WebhooksService
+	Anomalies *AnomaliesService
+	Budgets   *BudgetsService
+
+	// In-process event handlers registered via OnEvent, fanned out by
+	// WebhooksService.emit alongside signed deliveries to registered
+	// subscriptions.
+	eventMu       sync.RWMutex
+	eventHandlers map[EventType][]*eventHandler
+	nextHandlerID int64
 
 	// Metrics hooks (optional)
 	metrics MetricsCollector
 
+	// Middleware chain wrapping executeRequest (tracing, metrics,
+	// logging, idempotency keys, circuit breaking, ...)
+	handler Handler
+
+	// Response caching for GET requests to Pricing/Usage/Costs (optional)
+	cache                Cache
+	cacheTTL             time.Duration
+	staleWhileRevalidate bool
+
+	// In-flight request concurrency gate (optional, separate from the
+	// RPS rate limiter above)
+	concurrencySem           chan struct{}
+	concurrencyWaitThreshold time.Duration
+	longRunningMatcher       func(*http.Request) bool
+	inFlight                 int64
+
+	// Persistent outbox for offline/failed ingest (optional)
+	queueWorker *queueWorker
+
 	// Connection pooling
 	mu     sync.RWMutex
 	closed bool
@@ -113,27 +149,51 @@ type Client struct {
 
 // Config holds client configuration options
 type Config struct {
-	BaseURL    string
-	APIKey     string
-	HTTPClient *http.Client
-	Logger     *zap.Logger
-	MaxRetries int
-	RetryDelay time.Duration
-	RateLimit  rate.Limit
-	Timeout    time.Duration
-	Metrics    MetricsCollector
+	BaseURL                   string
+	Authenticator             auth.Authenticator
+	HTTPClient                *http.Client
+	Logger                    *zap.Logger
+	MaxRetries                int
+	RetryDelay                time.Duration
+	RateLimit                 rate.Limit
+	Timeout                   time.Duration
+	Metrics                   MetricsCollector
+	Middleware                []Middleware
+	RetryPolicy               *RetryPolicy
+	Cache                     Cache
+	CacheTTL                  time.Duration
+	StaleWhileRevalidate      bool
+	MaxConcurrentRequests     int
+	LongRunningRequestMatcher func(*http.Request) bool
+	ConcurrencyWaitThreshold  time.Duration
+	persistentQueue           Queue
 }
 
 // Option is a functional option for configuring the Client
 type Option func(*Config) error
 
-// WithAPIKey sets the API key for authentication
+// WithAPIKey sets the API key for authentication. It is sugar for
+// WithAuthenticator(auth.NewAPIKey(apiKey)), the SDK's original and still
+// most common authentication scheme.
 func WithAPIKey(apiKey string) Option {
 	return func(c *Config) error {
 		if apiKey == "" {
 			return fmt.Errorf("%w: API key cannot be empty", ErrInvalidConfig)
 		}
-		c.APIKey = apiKey
+		c.Authenticator = auth.NewAPIKey(apiKey)
+		return nil
+	}
+}
+
+// WithAuthenticator sets a custom Authenticator, for schemes beyond the
+// static API key WithAPIKey installs: OAuth2 client-credentials, HMAC
+// request signing, mTLS, or a caller's own implementation.
+func WithAuthenticator(a auth.Authenticator) Option {
+	return func(c *Config) error {
+		if a == nil {
+			return fmt.Errorf("%w: authenticator cannot be nil", ErrInvalidConfig)
+		}
+		c.Authenticator = a
 		return nil
 	}
 }
@@ -243,8 +303,8 @@ func NewClient(opts ...Option) (*Client, error) {
 	}
 
 	// Validate required fields
-	if config.APIKey == "" {
-		return nil, fmt.Errorf("%w: API key is required", ErrInvalidConfig)
+	if config.Authenticator == nil {
+		return nil, fmt.Errorf("%w: an authenticator is required (WithAPIKey or WithAuthenticator)", ErrInvalidConfig)
 	}
 
 	// Parse base URL
@@ -255,13 +315,17 @@ func NewClient(opts ...Option) (*Client, error) {
 
 	// Create HTTP client if not provided
 	if config.HTTPClient == nil {
+		transport := &http.Transport{
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 10,
+			IdleConnTimeout:     90 * time.Second,
+		}
+		if m, ok := config.Authenticator.(*auth.MTLS); ok {
+			transport.TLSClientConfig = m.TLSConfig()
+		}
 		config.HTTPClient = &http.Client{
-			Timeout: config.Timeout,
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 10,
-				IdleConnTimeout:     90 * time.Second,
-			},
+			Timeout:   config.Timeout,
+			Transport: transport,
 		}
 	}
 
@@ -270,24 +334,55 @@ func NewClient(opts ...Option) (*Client, error) {
 		config.Logger, _ = zap.NewProduction()
 	}
 
+	retryPolicy := DefaultRetryPolicy(config.MaxRetries, config.RetryDelay)
+	if config.RetryPolicy != nil {
+		retryPolicy = *config.RetryPolicy
+	}
+
 	// Create client
 	client := &Client{
-		baseURL:     baseURL,
-		apiKey:      config.APIKey,
-		httpClient:  config.HTTPClient,
-		logger:      config.Logger,
-		rateLimiter: rate.NewLimiter(config.RateLimit, int(config.RateLimit)),
-		maxRetries:  config.MaxRetries,
-		retryDelay:  config.RetryDelay,
-		metrics:     config.Metrics,
+		baseURL:       baseURL,
+		authenticator: config.Authenticator,
+		httpClient:    config.HTTPClient,
+		logger:        config.Logger,
+		rateLimiter:   rate.NewLimiter(config.RateLimit, int(config.RateLimit)),
+		maxRetries:    retryPolicy.MaxRetries,
+		retryDelay:    config.RetryDelay,
+		retryPolicy:   retryPolicy,
+		metrics:       config.Metrics,
 	}
 
+	client.handler = chainMiddleware(client.executeRequest, config.Middleware...)
+
+	client.cache = config.Cache
+	client.cacheTTL = config.CacheTTL
+	if client.cacheTTL <= 0 {
+		client.cacheTTL = DefaultCacheTTL
+	}
+	client.staleWhileRevalidate = config.StaleWhileRevalidate
+
+	if config.MaxConcurrentRequests > 0 {
+		client.concurrencySem = make(chan struct{}, config.MaxConcurrentRequests)
+	}
+	client.longRunningMatcher = config.LongRunningRequestMatcher
+	client.concurrencyWaitThreshold = config.ConcurrencyWaitThreshold
+
 	// Initialize service clients
 	client.Pricing = &PricingService{client: client}
 	client.Usage = &UsageService{client: client}
 	client.Costs = &CostService{client: client}
 	client.Export = &ExportService{client: client}
 	client.Health = &HealthService{client: client}
+	client.Alerts = &AlertService{client: client}
+	client.Rules = &RulesService{client: client}
+	client.Metrics = &MetricsService{client: client}
+	client.Webhooks = &WebhooksService{client: client}
+	client.Anomalies = &AnomaliesService{client: client}
+	client.Budgets = &BudgetsService{client: client}
+
+	if config.persistentQueue != nil {
+		client.queueWorker = startQueueWorker(client, config.persistentQueue)
+	}
 
 	return client, nil
 }
@@ -304,6 +399,10 @@ func (c *Client) Close() error {
 
 	c.closed = true
 
+	if c.queueWorker != nil {
+		c.queueWorker.Close()
+	}
+
 	// Sync logger
 	if c.logger != nil {
 		_ = c.logger.Sync()
@@ -313,54 +412,88 @@ func (c *Client) Close() error {
 }
 
 // do executes an HTTP request with retry logic and rate limiting.
-// It is goroutine-safe and respects context cancellation.
+// It is goroutine-safe and respects context cancellation. GET requests to
+// a cacheable endpoint are served (and populated) through c.cache instead
+// of always hitting the network.
 func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) error {
+	if c.cache != nil && req.Method == http.MethodGet && isCacheablePath(req.URL.Path) {
+		return c.doCached(ctx, req, v)
+	}
+
+	_, err := c.doWithMeta(ctx, req, v)
+	return err
+}
+
+// httpResponseMeta carries the raw response body and cache validators from
+// a doWithMeta call, for use by the caching layer.
+type httpResponseMeta struct {
+	ETag         string
+	LastModified string
+	StatusCode   int
+	Body         []byte
+}
+
+// doWithMeta is the core request/retry loop; do and doCached both build
+// on it.
+func (c *Client) doWithMeta(ctx context.Context, req *http.Request, v interface{}) (*httpResponseMeta, error) {
 	c.mu.RLock()
 	if c.closed {
 		c.mu.RUnlock()
-		return errors.New("client is closed")
+		return nil, errors.New("client is closed")
 	}
 	c.mu.RUnlock()
 
+	if c.concurrencySem != nil && !c.isLongRunning(req) {
+		release, err := c.acquireConcurrencySlot(ctx, req.Method)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
 	// Wait for rate limiter
 	if err := c.rateLimiter.Wait(ctx); err != nil {
-		return fmt.Errorf("%w: %v", ErrContextCanceled, err)
+		return nil, fmt.Errorf("%w: %v", ErrContextCanceled, err)
 	}
 
 	var lastErr error
 	startTime := time.Now()
+	policy := c.retryPolicy
+	refreshed := false
+
+	if req.Method == http.MethodPost || req.Method == http.MethodPut {
+		ctx = contextWithIdempotencyKey(ctx, uuid.NewString())
+	}
 
-	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+	for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
 		// Check context before retry
 		select {
 		case <-ctx.Done():
-			return fmt.Errorf("%w: %v", ErrContextCanceled, ctx.Err())
+			return nil, fmt.Errorf("%w: %v", ErrContextCanceled, ctx.Err())
 		default:
 		}
 
 		// Clone request for retry
 		reqClone := req.Clone(ctx)
 
-		// Execute request
-		resp, err := c.executeRequest(ctx, reqClone)
+		// Execute request through the middleware chain
+		resp, err := c.handler(contextWithAttempt(ctx, attempt), reqClone)
 		if err != nil {
 			lastErr = err
 			c.logRetry(attempt, err)
 
 			// Don't retry on context cancellation
 			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-				return fmt.Errorf("%w: %v", ErrContextCanceled, err)
+				return nil, fmt.Errorf("%w: %v", ErrContextCanceled, err)
 			}
 
-			// Exponential backoff
-			if attempt < c.maxRetries {
-				backoff := c.retryDelay * time.Duration(1<<uint(attempt))
-				select {
-				case <-time.After(backoff):
-					// Continue to retry
-				case <-ctx.Done():
-					return fmt.Errorf("%w: %v", ErrContextCanceled, ctx.Err())
-				}
+			if !c.isRetryableErr(err) || attempt >= policy.MaxRetries {
+				return nil, err
+			}
+
+			c.recordRetry(req.Method, attempt, "network error")
+			if !c.sleepForRetry(ctx, policy.delay(attempt, 0)) {
+				return nil, fmt.Errorf("%w: %v", ErrContextCanceled, ctx.Err())
 			}
 			continue
 		}
@@ -369,29 +502,53 @@ func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) error
 		if c.metrics != nil {
 			c.metrics.RecordRequest(req.Method, resp.StatusCode, time.Since(startTime))
 		}
+		retryAfter := parseRetryAfter(resp)
+		statusCode := resp.StatusCode
+		etag := resp.Header.Get("ETag")
+		lastModified := resp.Header.Get("Last-Modified")
 
 		// Handle response
-		err = c.handleResponse(resp, v)
+		body, err := c.handleResponse(resp, v)
+		meta := &httpResponseMeta{ETag: etag, LastModified: lastModified, StatusCode: statusCode, Body: body}
 		if err != nil {
+			// A single transparent refresh-and-retry on 401, before falling
+			// through to the normal retry/backoff handling below.
+			if statusCode == http.StatusUnauthorized && !refreshed {
+				refreshed = true
+				if rerr := c.authenticator.Refresh(ctx); rerr == nil {
+					attempt--
+					continue
+				}
+			}
+
+			// A 304 against a conditional cache revalidation isn't a
+			// failure; let the caching layer decide what to do with it.
+			if errors.Is(err, errNotModified) {
+				return meta, err
+			}
+
 			// Check if this is a server error that should be retried
-			if isRetryableError(err) && attempt < c.maxRetries {
+			shouldRetry := isRetryableError(err) || policy.retryableStatus(statusCode)
+			if policy.RetryableFn != nil {
+				shouldRetry = policy.RetryableFn(err)
+			}
+
+			if shouldRetry && attempt < policy.MaxRetries {
 				lastErr = err
 				c.logRetry(attempt, err)
-				backoff := c.retryDelay * time.Duration(1<<uint(attempt))
-				select {
-				case <-time.After(backoff):
-					continue
-				case <-ctx.Done():
-					return fmt.Errorf("%w: %v", ErrContextCanceled, ctx.Err())
+				c.recordRetry(req.Method, attempt, reasonForStatus(statusCode))
+				if !c.sleepForRetry(ctx, policy.delay(attempt, retryAfter)) {
+					return nil, fmt.Errorf("%w: %v", ErrContextCanceled, ctx.Err())
 				}
+				continue
 			}
-			return err
+			return nil, err
 		}
 
-		return nil
+		return meta, nil
 	}
 
-	return fmt.Errorf("request failed after %d attempts: %w", c.maxRetries+1, lastErr)
+	return nil, fmt.Errorf("request failed after %d attempts: %w", c.maxRetries+1, lastErr)
 }
 
 // isRetryableError checks if an error should trigger a retry
@@ -403,10 +560,54 @@ func isRetryableError(err error) bool {
 	return errors.Is(err, ErrServerError) || errors.Is(err, ErrRateLimited)
 }
 
+// isRetryableErr checks whether a transport-level error (one that never
+// reached handleResponse) should trigger a retry: transient network errors
+// such as timeouts, connection resets, and unexpected EOFs.
+func (c *Client) isRetryableErr(err error) bool {
+	if c.retryPolicy.RetryableFn != nil {
+		return c.retryPolicy.RetryableFn(err)
+	}
+	return retryableNetworkError(err)
+}
+
+// sleepForRetry blocks for d or until ctx is done, returning false in the
+// latter case.
+func (c *Client) sleepForRetry(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// recordRetry reports a retry attempt through the metrics collector, if it
+// implements RetryRecorder.
+func (c *Client) recordRetry(method string, attempt int, reason string) {
+	if recorder, ok := c.metrics.(RetryRecorder); ok {
+		recorder.RecordRetry(method, attempt, reason)
+	}
+}
+
+func reasonForStatus(statusCode int) string {
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		return "rate_limited"
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return "server_unavailable"
+	case http.StatusInternalServerError:
+		return "server_error"
+	default:
+		return fmt.Sprintf("status_%d", statusCode)
+	}
+}
+
 // executeRequest executes a single HTTP request
 func (c *Client) executeRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
-	// Set headers
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	// Authenticate
+	if err := c.authenticator.Apply(ctx, req); err != nil {
+		return nil, fmt.Errorf("failed to authenticate request: %w", err)
+	}
 	req.Header.Set("User-Agent", UserAgent)
 	req.Header.Set("Accept", "application/json")
 	if req.Method == http.MethodPost || req.Method == http.MethodPut || req.Method == http.MethodPatch {
@@ -428,29 +629,31 @@ func (c *Client) executeRequest(ctx context.Context, req *http.Request) (*http.R
 	return resp, nil
 }
 
-// handleResponse processes the HTTP response and decodes the body
-func (c *Client) handleResponse(resp *http.Response, v interface{}) error {
+// handleResponse processes the HTTP response, decodes the body into v,
+// and returns the raw body so callers that cache responses don't need to
+// re-marshal v.
+func (c *Client) handleResponse(resp *http.Response, v interface{}) ([]byte, error) {
 	defer resp.Body.Close()
 
 	// Read body
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	// Check status code
 	if err := c.checkResponseStatus(resp.StatusCode, body); err != nil {
-		return err
+		return body, err
 	}
 
 	// Decode response if needed
 	if v != nil && len(body) > 0 {
 		if err := json.Unmarshal(body, v); err != nil {
-			return fmt.Errorf("failed to decode response: %w", err)
+			return body, fmt.Errorf("failed to decode response: %w", err)
 		}
 	}
 
-	return nil
+	return body, nil
 }
 
 // checkResponseStatus checks the HTTP status code and returns appropriate errors
@@ -459,6 +662,10 @@ func (c *Client) checkResponseStatus(statusCode int, body []byte) error {
 		return nil
 	}
 
+	if statusCode == http.StatusNotModified {
+		return errNotModified
+	}
+
 	// Try to parse error response
 	var apiErr APIError
 	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
@@ -543,3 +750,10 @@ type MetricsCollector interface {
 	// RecordError records an error
 	RecordError(operation string, err error)
 }
+
+// RetryRecorder is an optional extension to MetricsCollector. Implement it
+// alongside MetricsCollector to receive a sample for every retry attempt
+// Client.do makes, so thundering-herd backoff behavior can be observed.
+type RetryRecorder interface {
+	RecordRetry(method string, attempt int, reason string)
+}
@@ -0,0 +1,102 @@
+package llmcostops
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// WithMaxConcurrentRequests caps the number of requests Client.do sends
+// at once, independent of the RPS rate limiter: rate.Limiter bounds
+// requests per second but does nothing to stop a caller that fans out
+// thousands of goroutines against a slow backend from exhausting file
+// descriptors or buffering too many response bodies at once. Acquiring a
+// slot is context-aware, so a canceled caller doesn't hold up others
+// waiting on the gate. n <= 0 disables the gate (the default).
+func WithMaxConcurrentRequests(n int) Option {
+	return func(c *Config) error {
+		if n < 0 {
+			return fmt.Errorf("%w: max concurrent requests cannot be negative", ErrInvalidConfig)
+		}
+		c.MaxConcurrentRequests = n
+		return nil
+	}
+}
+
+// WithLongRunningRequestMatcher exempts requests matching fn from the
+// WithMaxConcurrentRequests gate, mirroring Kubernetes apiserver's
+// MaxInFlight long-running-request handling: exports and streaming calls
+// can hold a connection open far longer than a typical request and
+// shouldn't compete with (or be throttled alongside) ordinary CRUD
+// traffic for the same pool of slots.
+func WithLongRunningRequestMatcher(fn func(*http.Request) bool) Option {
+	return func(c *Config) error {
+		c.LongRunningRequestMatcher = fn
+		return nil
+	}
+}
+
+// WithConcurrencyWaitThreshold sets how long a request may wait to
+// acquire a concurrency slot before it's reported through
+// ConcurrencyWaitRecorder, for alerting on a saturated gate. Defaults to
+// zero (no reporting).
+func WithConcurrencyWaitThreshold(d time.Duration) Option {
+	return func(c *Config) error {
+		c.ConcurrencyWaitThreshold = d
+		return nil
+	}
+}
+
+// ConcurrencyWaitRecorder is an optional extension to MetricsCollector.
+// Implement it alongside MetricsCollector to be notified when a request
+// waits at least Client's configured WithConcurrencyWaitThreshold to
+// acquire a concurrency slot.
+type ConcurrencyWaitRecorder interface {
+	RecordConcurrencyWait(method string, waited time.Duration)
+}
+
+// InFlight returns the number of requests currently holding a
+// concurrency slot. It is always zero if WithMaxConcurrentRequests
+// wasn't configured.
+func (c *Client) InFlight() int {
+	return int(atomic.LoadInt64(&c.inFlight))
+}
+
+// isLongRunning reports whether req should bypass the concurrency gate.
+func (c *Client) isLongRunning(req *http.Request) bool {
+	return c.longRunningMatcher != nil && c.longRunningMatcher(req)
+}
+
+// acquireConcurrencySlot blocks until a concurrency slot is free or ctx
+// is done, returning a release func to call once the request completes.
+func (c *Client) acquireConcurrencySlot(ctx context.Context, method string) (func(), error) {
+	start := time.Now()
+
+	select {
+	case c.concurrencySem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%w: %v", ErrContextCanceled, ctx.Err())
+	}
+
+	if c.concurrencyWaitThreshold > 0 {
+		if waited := time.Since(start); waited >= c.concurrencyWaitThreshold {
+			c.recordConcurrencyWait(method, waited)
+		}
+	}
+
+	atomic.AddInt64(&c.inFlight, 1)
+	return func() {
+		atomic.AddInt64(&c.inFlight, -1)
+		<-c.concurrencySem
+	}, nil
+}
+
+// recordConcurrencyWait reports a slow concurrency-slot acquisition
+// through the metrics collector, if it implements ConcurrencyWaitRecorder.
+func (c *Client) recordConcurrencyWait(method string, waited time.Duration) {
+	if recorder, ok := c.metrics.(ConcurrencyWaitRecorder); ok {
+		recorder.RecordConcurrencyWait(method, waited)
+	}
+}
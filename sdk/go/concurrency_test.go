@@ -0,0 +1,98 @@
+package llmcostops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_MaxConcurrentRequests(t *testing.T) {
+	var inFlight, maxObserved int64
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt64(&inFlight, 1)
+		for {
+			old := atomic.LoadInt64(&maxObserved)
+			if n <= old || atomic.CompareAndSwapInt64(&maxObserved, old, n) {
+				break
+			}
+		}
+		<-release
+		atomic.AddInt64(&inFlight, -1)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(HealthStatus{Status: "ok"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithMaxConcurrentRequests(2),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Health.Check(context.Background())
+		}()
+	}
+
+	// Give the goroutines a moment to pile up against the gate, then
+	// confirm InFlight never reports more than the configured cap.
+	time.Sleep(50 * time.Millisecond)
+	if got := client.InFlight(); got > 2 {
+		t.Errorf("InFlight() = %d, want <= 2", got)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if atomic.LoadInt64(&maxObserved) > 2 {
+		t.Errorf("max concurrent server-side requests = %d, want <= 2", maxObserved)
+	}
+}
+
+func TestClient_LongRunningRequestMatcherBypassesGate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(HealthStatus{Status: "ok"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithMaxConcurrentRequests(1),
+		WithLongRunningRequestMatcher(func(req *http.Request) bool {
+			return req.URL.Path == "/health"
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	// Occupy the single concurrency slot directly, as if another request
+	// were already in flight.
+	client.concurrencySem <- struct{}{}
+	defer func() { <-client.concurrencySem }()
+
+	// ...and confirm a matched "long running" request still goes through.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := client.Health.Check(ctx); err != nil {
+		t.Errorf("exempted request should bypass the gate, got error: %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package llmcostops
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables read by NewClientFromEnv and WithProfile's
+// env-based defaults.
+const (
+	EnvAPIKey     = "LLMCOSTOPS_API_KEY"
+	EnvBaseURL    = "LLMCOSTOPS_BASE_URL"
+	EnvTimeout    = "LLMCOSTOPS_TIMEOUT"
+	EnvRateLimit  = "LLMCOSTOPS_RATE_LIMIT"
+	EnvMaxRetries = "LLMCOSTOPS_MAX_RETRIES"
+	EnvRetryDelay = "LLMCOSTOPS_RETRY_DELAY"
+	EnvConfigFile = "LLMCOSTOPS_CONFIG_FILE"
+	EnvProfile    = "LLMCOSTOPS_PROFILE"
+)
+
+// DefaultConfigFile is where WithProfile looks for a config file when
+// EnvConfigFile isn't set, mirroring the ~/.<tool>/config convention used
+// by linodego and similar CLIs/SDKs.
+const DefaultConfigFile = ".costops/config.yaml"
+
+// ProfileConfig is one named profile (e.g. "dev", "staging", "prod") in a
+// multi-profile YAML or TOML config file.
+type ProfileConfig struct {
+	BaseURL    string        `yaml:"base_url" toml:"base_url"`
+	APIKey     string        `yaml:"api_key" toml:"api_key"`
+	Timeout    time.Duration `yaml:"timeout" toml:"timeout"`
+	RateLimit  float64       `yaml:"rate_limit" toml:"rate_limit"`
+	MaxRetries int           `yaml:"max_retries" toml:"max_retries"`
+	RetryDelay time.Duration `yaml:"retry_delay" toml:"retry_delay"`
+}
+
+// configFile is the top-level shape of a multi-profile config file.
+type configFile struct {
+	Profiles map[string]ProfileConfig `yaml:"profiles" toml:"profiles"`
+}
+
+// NewClientFromEnv builds a Client from LLMCOSTOPS_* environment
+// variables (LLMCOSTOPS_API_KEY, LLMCOSTOPS_BASE_URL,
+// LLMCOSTOPS_TIMEOUT, LLMCOSTOPS_RATE_LIMIT, LLMCOSTOPS_MAX_RETRIES,
+// LLMCOSTOPS_RETRY_DELAY), eliminating the boilerplate of wiring
+// os.Getenv calls into NewClient by hand. Only variables that are set
+// override the client's defaults.
+func NewClientFromEnv() (*Client, error) {
+	opts, err := envOptions()
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(opts...)
+}
+
+// NewClientFromConfigFile builds a Client from the named profile in the
+// YAML or TOML config file at path (format is inferred from the
+// extension; .toml is read as TOML, everything else as YAML).
+func NewClientFromConfigFile(path, profile string) (*Client, error) {
+	return NewClient(WithConfigFile(path, profile))
+}
+
+// WithConfigFile applies the named profile from the YAML or TOML config
+// file at path, setting base URL, API key, timeout, rate limit, and
+// retry policy together from a single section of the file.
+func WithConfigFile(path, profile string) Option {
+	return func(c *Config) error {
+		prof, err := loadProfile(path, profile)
+		if err != nil {
+			return err
+		}
+		return applyProfile(c, prof)
+	}
+}
+
+// WithProfile applies a named profile the same way WithConfigFile does,
+// but reads the file from LLMCOSTOPS_CONFIG_FILE, or
+// ~/.costops/config.yaml if that isn't set — so a single
+// WithProfile("prod") call configures base URL, timeout, rate limit, and
+// retry policy together without the caller naming a path.
+func WithProfile(name string) Option {
+	return func(c *Config) error {
+		path := os.Getenv(EnvConfigFile)
+		if path == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return fmt.Errorf("%w: could not resolve default config file location: %v", ErrInvalidConfig, err)
+			}
+			path = filepath.Join(home, DefaultConfigFile)
+		}
+
+		prof, err := loadProfile(path, name)
+		if err != nil {
+			return err
+		}
+		return applyProfile(c, prof)
+	}
+}
+
+func loadProfile(path, profile string) (ProfileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ProfileConfig{}, fmt.Errorf("%w: failed to read config file %q: %v", ErrInvalidConfig, path, err)
+	}
+
+	var file configFile
+	if filepath.Ext(path) == ".toml" {
+		if err := toml.Unmarshal(data, &file); err != nil {
+			return ProfileConfig{}, fmt.Errorf("%w: failed to parse TOML config file %q: %v", ErrInvalidConfig, path, err)
+		}
+	} else {
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return ProfileConfig{}, fmt.Errorf("%w: failed to parse YAML config file %q: %v", ErrInvalidConfig, path, err)
+		}
+	}
+
+	prof, ok := file.Profiles[profile]
+	if !ok {
+		return ProfileConfig{}, fmt.Errorf("%w: no profile %q in config file %q", ErrInvalidConfig, profile, path)
+	}
+	return prof, nil
+}
+
+// applyProfile overrides c's fields with any non-zero values set in prof.
+func applyProfile(c *Config, prof ProfileConfig) error {
+	if prof.APIKey != "" {
+		if err := WithAPIKey(prof.APIKey)(c); err != nil {
+			return err
+		}
+	}
+	if prof.BaseURL != "" {
+		if err := WithBaseURL(prof.BaseURL)(c); err != nil {
+			return err
+		}
+	}
+	if prof.Timeout > 0 {
+		if err := WithTimeout(prof.Timeout)(c); err != nil {
+			return err
+		}
+	}
+	if prof.RateLimit > 0 {
+		if err := WithRateLimit(prof.RateLimit)(c); err != nil {
+			return err
+		}
+	}
+	if prof.MaxRetries > 0 {
+		if err := WithMaxRetries(prof.MaxRetries)(c); err != nil {
+			return err
+		}
+	}
+	if prof.RetryDelay > 0 {
+		if err := WithRetryDelay(prof.RetryDelay)(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// envOptions translates the LLMCOSTOPS_* environment variables into
+// Options, wrapping any parse failure in ErrInvalidConfig. If
+// LLMCOSTOPS_PROFILE is set, it's applied first via WithProfile so the
+// other LLMCOSTOPS_* variables can still override individual fields.
+func envOptions() ([]Option, error) {
+	var opts []Option
+
+	if v := os.Getenv(EnvProfile); v != "" {
+		opts = append(opts, WithProfile(v))
+	}
+
+	if v := os.Getenv(EnvAPIKey); v != "" {
+		opts = append(opts, WithAPIKey(v))
+	}
+	if v := os.Getenv(EnvBaseURL); v != "" {
+		opts = append(opts, WithBaseURL(v))
+	}
+	if v := os.Getenv(EnvTimeout); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid %s %q: %v", ErrInvalidConfig, EnvTimeout, v, err)
+		}
+		opts = append(opts, WithTimeout(d))
+	}
+	if v := os.Getenv(EnvRateLimit); v != "" {
+		rps, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid %s %q: %v", ErrInvalidConfig, EnvRateLimit, v, err)
+		}
+		opts = append(opts, WithRateLimit(rps))
+	}
+	if v := os.Getenv(EnvMaxRetries); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid %s %q: %v", ErrInvalidConfig, EnvMaxRetries, v, err)
+		}
+		opts = append(opts, WithMaxRetries(n))
+	}
+	if v := os.Getenv(EnvRetryDelay); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid %s %q: %v", ErrInvalidConfig, EnvRetryDelay, v, err)
+		}
+		opts = append(opts, WithRetryDelay(d))
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,87 @@
+package llmcostops
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testConfigYAML = `
+profiles:
+  dev:
+    base_url: https://dev.costops.example.com
+    api_key: dev-key
+    timeout: 5s
+    max_retries: 1
+  prod:
+    base_url: https://api.costops.example.com
+    api_key: prod-key
+    timeout: 30s
+    rate_limit: 50
+    max_retries: 5
+    retry_delay: 200ms
+`
+
+func TestWithConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(testConfigYAML), 0o600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		profile    string
+		wantErr    bool
+		wantMaxTry int
+	}{
+		{name: "dev profile", profile: "dev", wantMaxTry: 1},
+		{name: "prod profile", profile: "prod", wantMaxTry: 5},
+		{name: "missing profile", profile: "nope", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClientFromConfigFile(path, tt.profile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewClientFromConfigFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !isError(err, ErrInvalidConfig) {
+					t.Errorf("expected ErrInvalidConfig, got %v", err)
+				}
+				return
+			}
+			if client.maxRetries != tt.wantMaxTry {
+				t.Errorf("maxRetries = %d, want %d", client.maxRetries, tt.wantMaxTry)
+			}
+		})
+	}
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	t.Setenv(EnvAPIKey, "env-key")
+	t.Setenv(EnvBaseURL, "https://env.costops.example.com")
+	t.Setenv(EnvTimeout, "2s")
+	t.Setenv(EnvMaxRetries, "7")
+
+	client, err := NewClientFromEnv()
+	if err != nil {
+		t.Fatalf("NewClientFromEnv() error = %v", err)
+	}
+	if client.baseURL.String() != "https://env.costops.example.com" {
+		t.Errorf("baseURL = %s, want https://env.costops.example.com", client.baseURL.String())
+	}
+	if client.maxRetries != 7 {
+		t.Errorf("maxRetries = %d, want 7", client.maxRetries)
+	}
+}
+
+func TestNewClientFromEnv_InvalidDuration(t *testing.T) {
+	t.Setenv(EnvAPIKey, "env-key")
+	t.Setenv(EnvTimeout, "not-a-duration")
+
+	_, err := NewClientFromEnv()
+	if !isError(err, ErrInvalidConfig) {
+		t.Errorf("expected ErrInvalidConfig, got %v", err)
+	}
+}
@@ -0,0 +1,168 @@
+package llmcostops
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultCostScale is the number of decimal places CostCalculator rounds a
+// computed cost to when CostCalculatorOptions.Scale has no entry for the
+// currency in question.
+const DefaultCostScale = 6
+
+var million = decimal.NewFromInt(1_000_000)
+
+// CostCalculatorOptions configures a CostCalculator.
+type CostCalculatorOptions struct {
+	// Rounding selects the rounding mode applied to each computed cost.
+	// Defaults to RoundHalfEven.
+	Rounding RoundingMode
+
+	// Scale maps a Currency to the number of decimal places its costs are
+	// rounded to. A currency with no entry uses DefaultCostScale.
+	Scale map[Currency]int32
+}
+
+func (o CostCalculatorOptions) withDefaults() CostCalculatorOptions {
+	if o.Scale == nil {
+		o.Scale = map[Currency]int32{}
+	}
+	return o
+}
+
+// CostCalculator computes input/output/total cost from a PricingStructure
+// and token counts using exact decimal arithmetic, so summing millions of
+// small per-token costs doesn't accumulate the drift a float64 computation
+// would.
+type CostCalculator struct {
+	opts CostCalculatorOptions
+}
+
+// NewCostCalculator returns a CostCalculator configured by opts.
+func NewCostCalculator(opts CostCalculatorOptions) *CostCalculator {
+	return &CostCalculator{opts: opts.withDefaults()}
+}
+
+// CostBreakdown is the result of CostCalculator.Calculate.
+type CostBreakdown struct {
+	InputCost  Money
+	OutputCost Money
+	TotalCost  Money
+}
+
+func (c *CostCalculator) scaleFor(currency Currency) int32 {
+	if s, ok := c.opts.Scale[currency]; ok {
+		return s
+	}
+	return DefaultCostScale
+}
+
+func (c *CostCalculator) money(d decimal.Decimal, currency Currency) Money {
+	return Money{Amount: c.opts.Rounding.round(d, c.scaleFor(currency)), Currency: currency}
+}
+
+// Calculate computes the cost of a request against pricing, given its
+// prompt/completion token counts and how many of the prompt tokens were
+// served from cache. cachedTokens must be <= promptTokens.
+func (c *CostCalculator) Calculate(pricing *PricingStructure, currency Currency, promptTokens, completionTokens, cachedTokens int64) (*CostBreakdown, error) {
+	if pricing == nil {
+		return nil, fmt.Errorf("%w: pricing is required", ErrBadRequest)
+	}
+	if err := pricing.Validate(); err != nil {
+		return nil, err
+	}
+	if cachedTokens < 0 || cachedTokens > promptTokens {
+		return nil, fmt.Errorf("%w: cached_tokens must be between 0 and prompt_tokens", ErrBadRequest)
+	}
+
+	switch pricing.Type {
+	case "per_token":
+		return c.calculatePerToken(pricing.InputPricePerMillion, pricing.OutputPricePerMillion, pricing.CachedInputDiscount, currency, promptTokens, completionTokens, cachedTokens)
+	case "per_request":
+		return c.calculatePerRequest(pricing, currency, promptTokens+completionTokens)
+	case "tiered":
+		return c.calculateTiered(pricing, currency, promptTokens, completionTokens, cachedTokens)
+	default:
+		return nil, fmt.Errorf("%w: unknown pricing structure type %q", ErrBadRequest, pricing.Type)
+	}
+}
+
+// calculatePerToken prices promptTokens/completionTokens at
+// inputPrice/outputPrice per million tokens, applying discount (if any) to
+// the cachedTokens share of promptTokens.
+func (c *CostCalculator) calculatePerToken(inputPrice, outputPrice, discount *Decimal, currency Currency, promptTokens, completionTokens, cachedTokens int64) (*CostBreakdown, error) {
+	if inputPrice == nil || outputPrice == nil {
+		return nil, fmt.Errorf("%w: per_token pricing requires input and output prices", ErrBadRequest)
+	}
+
+	uncachedTokens := promptTokens - cachedTokens
+	inputCost := decimal.NewFromInt(uncachedTokens).Div(million).Mul(inputPrice.Decimal)
+
+	if cachedTokens > 0 {
+		cachedRate := inputPrice.Decimal
+		if discount != nil {
+			cachedRate = cachedRate.Mul(decimal.NewFromInt(1).Sub(discount.Decimal))
+		}
+		inputCost = inputCost.Add(decimal.NewFromInt(cachedTokens).Div(million).Mul(cachedRate))
+	}
+
+	outputCost := decimal.NewFromInt(completionTokens).Div(million).Mul(outputPrice.Decimal)
+
+	return c.breakdown(inputCost, outputCost, currency), nil
+}
+
+// calculatePerRequest prices a flat PricePerRequest plus, once totalTokens
+// exceeds IncludedTokens, an overage charged at OveragePricePerMillion.
+func (c *CostCalculator) calculatePerRequest(pricing *PricingStructure, currency Currency, totalTokens int64) (*CostBreakdown, error) {
+	if pricing.PricePerRequest == nil {
+		return nil, fmt.Errorf("%w: per_request pricing requires price_per_request", ErrBadRequest)
+	}
+
+	baseCost := pricing.PricePerRequest.Decimal
+	overageCost := decimal.Zero
+
+	if pricing.IncludedTokens != nil && totalTokens > *pricing.IncludedTokens {
+		overageTokens := totalTokens - *pricing.IncludedTokens
+		if pricing.OveragePricePerMillion != nil {
+			overageCost = decimal.NewFromInt(overageTokens).Div(million).Mul(pricing.OveragePricePerMillion.Decimal)
+		}
+	}
+
+	return c.breakdown(baseCost, overageCost, currency), nil
+}
+
+// calculateTiered selects the highest-threshold tier whose Threshold does
+// not exceed promptTokens+completionTokens and prices the request at that
+// tier's per-token rates. Tiers need not be pre-sorted.
+func (c *CostCalculator) calculateTiered(pricing *PricingStructure, currency Currency, promptTokens, completionTokens, cachedTokens int64) (*CostBreakdown, error) {
+	if len(pricing.Tiers) == 0 {
+		return nil, fmt.Errorf("%w: tiered pricing requires at least one tier", ErrBadRequest)
+	}
+
+	tiers := append([]PricingTier(nil), pricing.Tiers...)
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].Threshold < tiers[j].Threshold })
+
+	totalTokens := promptTokens + completionTokens
+	tier := tiers[0]
+	for _, t := range tiers {
+		if t.Threshold > totalTokens {
+			break
+		}
+		tier = t
+	}
+
+	inputPrice, outputPrice := tier.InputPricePerMillion, tier.OutputPricePerMillion
+	return c.calculatePerToken(&inputPrice, &outputPrice, pricing.CachedInputDiscount, currency, promptTokens, completionTokens, cachedTokens)
+}
+
+func (c *CostCalculator) breakdown(inputCost, outputCost decimal.Decimal, currency Currency) *CostBreakdown {
+	inputMoney := c.money(inputCost, currency)
+	outputMoney := c.money(outputCost, currency)
+	return &CostBreakdown{
+		InputCost:  inputMoney,
+		OutputCost: outputMoney,
+		TotalCost:  c.money(inputCost.Add(outputCost), currency),
+	}
+}
@@ -0,0 +1,206 @@
+package llmcostops
+
+import "testing"
+
+func assertMoneyEquals(t *testing.T, label string, got Money, want string) {
+	t.Helper()
+	w := MustDecimal(want)
+	if !got.Amount.Equal(w.Decimal) {
+		t.Errorf("%s = %s, want %s", label, got, want)
+	}
+}
+
+func TestCostCalculator_PerToken(t *testing.T) {
+	calc := NewCostCalculator(CostCalculatorOptions{})
+
+	pricing := &PricingStructure{
+		Type:                  "per_token",
+		InputPricePerMillion:  decimalPtr(10.0),
+		OutputPricePerMillion: decimalPtr(30.0),
+	}
+
+	got, err := calc.Calculate(pricing, CurrencyUSD, 1_000_000, 500_000, 0)
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	assertMoneyEquals(t, "InputCost", got.InputCost, "10")
+	assertMoneyEquals(t, "OutputCost", got.OutputCost, "15")
+	assertMoneyEquals(t, "TotalCost", got.TotalCost, "25")
+}
+
+func TestCostCalculator_PerToken_CachedDiscount(t *testing.T) {
+	calc := NewCostCalculator(CostCalculatorOptions{})
+
+	pricing := &PricingStructure{
+		Type:                  "per_token",
+		InputPricePerMillion:  decimalPtr(10.0),
+		OutputPricePerMillion: decimalPtr(30.0),
+		CachedInputDiscount:   decimalPtr(0.5), // 50% off cached tokens
+	}
+
+	// 1M prompt tokens, half of them cached: 500k at full rate + 500k at
+	// half rate = (5 + 2.5) = 7.5
+	got, err := calc.Calculate(pricing, CurrencyUSD, 1_000_000, 0, 500_000)
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	assertMoneyEquals(t, "InputCost", got.InputCost, "7.5")
+}
+
+func TestCostCalculator_PerToken_RejectsCachedTokensExceedingPrompt(t *testing.T) {
+	calc := NewCostCalculator(CostCalculatorOptions{})
+	pricing := &PricingStructure{
+		Type:                  "per_token",
+		InputPricePerMillion:  decimalPtr(10.0),
+		OutputPricePerMillion: decimalPtr(30.0),
+	}
+
+	if _, err := calc.Calculate(pricing, CurrencyUSD, 100, 0, 200); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestCostCalculator_PerRequest_WithOverage(t *testing.T) {
+	calc := NewCostCalculator(CostCalculatorOptions{})
+
+	included := int64(1_000_000)
+	pricing := &PricingStructure{
+		Type:                   "per_request",
+		PricePerRequest:        decimalPtr(0.01),
+		IncludedTokens:         &included,
+		OveragePricePerMillion: decimalPtr(20.0),
+	}
+
+	got, err := calc.Calculate(pricing, CurrencyUSD, 1_500_000, 0, 0)
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	// 500k overage tokens at $20/million = $10, plus the $0.01 base.
+	assertMoneyEquals(t, "TotalCost", got.TotalCost, "10.01")
+}
+
+func TestCostCalculator_PerRequest_NoOverageWithinIncluded(t *testing.T) {
+	calc := NewCostCalculator(CostCalculatorOptions{})
+
+	included := int64(1_000_000)
+	pricing := &PricingStructure{
+		Type:                   "per_request",
+		PricePerRequest:        decimalPtr(0.01),
+		IncludedTokens:         &included,
+		OveragePricePerMillion: decimalPtr(20.0),
+	}
+
+	got, err := calc.Calculate(pricing, CurrencyUSD, 500_000, 0, 0)
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	assertMoneyEquals(t, "TotalCost", got.TotalCost, "0.01")
+}
+
+func TestCostCalculator_Tiered(t *testing.T) {
+	calc := NewCostCalculator(CostCalculatorOptions{})
+
+	pricing := &PricingStructure{
+		Type: "tiered",
+		Tiers: []PricingTier{
+			{Threshold: 0, InputPricePerMillion: NewDecimalFromFloat(10.0), OutputPricePerMillion: NewDecimalFromFloat(30.0)},
+			{Threshold: 1_000_000, InputPricePerMillion: NewDecimalFromFloat(8.0), OutputPricePerMillion: NewDecimalFromFloat(24.0)},
+		},
+	}
+
+	// 2M total tokens crosses the 1M threshold, so the discounted tier applies.
+	got, err := calc.Calculate(pricing, CurrencyUSD, 2_000_000, 0, 0)
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	assertMoneyEquals(t, "InputCost", got.InputCost, "16")
+}
+
+func TestCostCalculator_RoundingModeAppliesToResult(t *testing.T) {
+	pricing := &PricingStructure{
+		Type:                  "per_token",
+		InputPricePerMillion:  decimalPtr(3.0),
+		OutputPricePerMillion: decimalPtr(0),
+	}
+
+	// 1 token at $3/million = 0.000003, rounded away to 2 decimal places.
+	halfUp := NewCostCalculator(CostCalculatorOptions{Rounding: RoundHalfUp, Scale: map[Currency]int32{CurrencyUSD: 2}})
+	got, err := halfUp.Calculate(pricing, CurrencyUSD, 1, 0, 0)
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	assertMoneyEquals(t, "InputCost", got.InputCost, "0.00")
+}
+
+func TestCostCalculator_Calculate_RejectsNegativePricing(t *testing.T) {
+	calc := NewCostCalculator(CostCalculatorOptions{})
+	pricing := &PricingStructure{
+		Type:                  "per_token",
+		InputPricePerMillion:  decimalPtr(-1),
+		OutputPricePerMillion: decimalPtr(30.0),
+	}
+
+	if _, err := calc.Calculate(pricing, CurrencyUSD, 100, 0, 0); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestPricingStructure_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       PricingStructure
+		wantErr bool
+	}{
+		{
+			name: "valid per_token",
+			p: PricingStructure{
+				Type:                  "per_token",
+				InputPricePerMillion:  decimalPtr(10.0),
+				OutputPricePerMillion: decimalPtr(30.0),
+			},
+		},
+		{
+			name: "negative price",
+			p: PricingStructure{
+				Type:                  "per_token",
+				InputPricePerMillion:  decimalPtr(-0.01),
+				OutputPricePerMillion: decimalPtr(30.0),
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative included tokens",
+			p: PricingStructure{
+				Type:           "per_request",
+				IncludedTokens: int64Ptr(-1),
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative tier threshold",
+			p: PricingStructure{
+				Type: "tiered",
+				Tiers: []PricingTier{
+					{Threshold: -1, InputPricePerMillion: NewDecimalFromFloat(1), OutputPricePerMillion: NewDecimalFromFloat(1)},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.p.Validate()
+			if tt.wantErr && err == nil {
+				t.Error("Validate() = nil, want error")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Validate() error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
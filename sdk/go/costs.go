@@ -23,13 +23,38 @@ type CostListParams struct {
 	Provider       Provider   `json:"provider,omitempty"`
 	Model          string     `json:"model,omitempty"`
 	UserID         string     `json:"user_id,omitempty"`
-	MinCost        *float64   `json:"min_cost,omitempty"`
-	MaxCost        *float64   `json:"max_cost,omitempty"`
+	MinCost        *Decimal   `json:"min_cost,omitempty"`
+	MaxCost        *Decimal   `json:"max_cost,omitempty"`
 	SortBy         string     `json:"sort_by,omitempty"`
 	SortOrder      SortOrder  `json:"sort_order,omitempty"`
 	PaginationParams
 }
 
+// ListIterator returns an Iterator over cost records matching params,
+// fetching additional pages from List as the caller consumes them. params
+// may be nil; its Page/PageSize are overwritten as the iterator pages
+// through the list, so a zero PageSize just selects the default page size.
+func (s *CostService) ListIterator(params *CostListParams) *Iterator[CostRecord] {
+	p := CostListParams{}
+	if params != nil {
+		p = *params
+	}
+	pageSize := p.PageSize
+
+	return NewIterator(pageSize, func(ctx context.Context, page, pageSize int) ([]CostRecord, error) {
+		pageParams := p
+		pageParams.Page = page
+		pageParams.PageSize = pageSize
+		return s.List(ctx, &pageParams)
+	})
+}
+
+// ListAll drains ListIterator into a single slice, fetching every page of
+// cost records matching params.
+func (s *CostService) ListAll(ctx context.Context, params *CostListParams) ([]CostRecord, error) {
+	return All(ctx, s.ListIterator(params))
+}
+
 // CostSummaryParams represents parameters for cost summary
 type CostSummaryParams struct {
 	Range          TimeRange  `json:"range,omitempty"`
@@ -47,6 +72,8 @@ type CostAnalyticsParams struct {
 	EndTime        *time.Time `json:"end_time,omitempty"`
 	OrganizationID string     `json:"organization_id,omitempty"`
 	ProjectID      string     `json:"project_id,omitempty"`
+	Provider       Provider   `json:"provider,omitempty"`
+	Model          string     `json:"model,omitempty"`
 	Granularity    string     `json:"granularity,omitempty"` // "hour", "day", "week", "month"
 }
 
@@ -127,10 +154,10 @@ func (s *CostService) List(ctx context.Context, params *CostListParams) ([]CostR
 			q.Set("user_id", params.UserID)
 		}
 		if params.MinCost != nil {
-			q.Set("min_cost", fmt.Sprintf("%.10f", *params.MinCost))
+			q.Set("min_cost", params.MinCost.String())
 		}
 		if params.MaxCost != nil {
-			q.Set("max_cost", fmt.Sprintf("%.10f", *params.MaxCost))
+			q.Set("max_cost", params.MaxCost.String())
 		}
 		if params.SortBy != "" {
 			q.Set("sort_by", params.SortBy)
@@ -219,6 +246,12 @@ func (s *CostService) Analytics(ctx context.Context, params *CostAnalyticsParams
 		if params.ProjectID != "" {
 			q.Set("project_id", params.ProjectID)
 		}
+		if params.Provider != "" {
+			q.Set("provider", string(params.Provider))
+		}
+		if params.Model != "" {
+			q.Set("model", params.Model)
+		}
 		if params.Granularity != "" {
 			q.Set("granularity", params.Granularity)
 		}
@@ -0,0 +1,74 @@
+package llmcostops
+
+import (
+	"sync"
+	"time"
+)
+
+// deadlineTimer implements a settable, re-armable deadline as a channel
+// that closes when the deadline passes, mirroring the pattern net.Conn
+// implementations use for SetReadDeadline/SetWriteDeadline: an AfterFunc
+// timer closes the channel on expiry, and set/stop swap in a fresh channel
+// so a past deadline doesn't leak into the next one. Streaming operations
+// (UsageService.Stream, IngestSession, exportStream) select on c() alongside their
+// I/O so a slow consumer or producer unblocks without leaking the
+// goroutine driving the underlying connection.
+type deadlineTimer struct {
+	mu    sync.Mutex
+	timer *time.Timer
+	done  chan struct{}
+}
+
+func newDeadlineTimer() *deadlineTimer {
+	return &deadlineTimer{done: make(chan struct{})}
+}
+
+// set arms the deadline at t. A zero t disarms it (the stream never times
+// out on its own). A t already in the past fires immediately.
+func (d *deadlineTimer) set(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.reset()
+	if t.IsZero() {
+		return
+	}
+
+	dur := time.Until(t)
+	if dur <= 0 {
+		close(d.done)
+		return
+	}
+
+	done := d.done
+	d.timer = time.AfterFunc(dur, func() { close(done) })
+}
+
+// stop disarms the deadline and re-arms a fresh, unfired channel for the
+// next set call.
+func (d *deadlineTimer) stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.reset()
+}
+
+// reset stops any pending timer and swaps in a fresh done channel if the
+// current one has already fired. Callers must hold d.mu.
+func (d *deadlineTimer) reset() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	select {
+	case <-d.done:
+		d.done = make(chan struct{})
+	default:
+	}
+}
+
+// c returns the channel that closes when the deadline passes.
+func (d *deadlineTimer) c() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.done
+}
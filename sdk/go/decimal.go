@@ -0,0 +1,102 @@
+package llmcostops
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Decimal is an exact-precision numeric value for fields that must stay
+// JSON numbers on the wire — prices, discounts, thresholds — as opposed to
+// Money's decimal-string cost fields. It exists so a caller can configure
+// PricingAddParams.InputPricePerMillion as 0.000003 without the float64
+// rounding that accumulates into visible drift once millions of per-token
+// costs are summed.
+type Decimal struct {
+	decimal.Decimal
+}
+
+// NewDecimalFromFloat constructs a Decimal from a float64 literal, for
+// callers migrating existing float64 call sites. Prefer NewDecimalFromString
+// when the value originates as text, since a float64 has already lost any
+// precision beyond its 53 mantissa bits.
+func NewDecimalFromFloat(f float64) Decimal {
+	return Decimal{decimal.NewFromFloat(f)}
+}
+
+// NewDecimalFromString parses a decimal literal exactly, with no
+// intermediate float64 conversion.
+func NewDecimalFromString(s string) (Decimal, error) {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("%w: invalid decimal %q: %v", ErrBadRequest, s, err)
+	}
+	return Decimal{d}, nil
+}
+
+// MustDecimal parses a decimal literal, panicking on failure. It is meant
+// for package-level constants and tests, not for parsing untrusted input.
+func MustDecimal(s string) Decimal {
+	d, err := NewDecimalFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// MarshalJSON renders d as a bare JSON number, e.g. 0.000003, matching the
+// wire format of the float64 fields it replaces.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.Decimal.String()), nil
+}
+
+// UnmarshalJSON parses a JSON number (or a quoted decimal string, for
+// servers that send one) into d without a float64 round-trip.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	if s == "" || s == "null" {
+		d.Decimal = decimal.Decimal{}
+		return nil
+	}
+	parsed, err := decimal.NewFromString(s)
+	if err != nil {
+		return fmt.Errorf("failed to decode Decimal: %w", err)
+	}
+	d.Decimal = parsed
+	return nil
+}
+
+// maxPricingScale bounds how many decimal places a PricingStructure price
+// may carry. It's generous enough for the fractional-cent-per-token prices
+// real providers publish (e.g. 0.0000005) while still catching a caller
+// that accidentally passes a value with absurd, clearly-corrupted precision.
+const maxPricingScale = 18
+
+// RoundingMode selects how CostCalculator rounds a computed cost to its
+// configured scale.
+type RoundingMode int
+
+const (
+	// RoundHalfEven rounds to the nearest value, ties to the nearest even
+	// digit ("banker's rounding"). This is the default: it doesn't bias
+	// sums of many small costs the way HALF_UP does.
+	RoundHalfEven RoundingMode = iota
+	// RoundHalfUp rounds to the nearest value, ties away from zero.
+	RoundHalfUp
+	// RoundDown truncates toward zero.
+	RoundDown
+)
+
+func (m RoundingMode) round(d decimal.Decimal, scale int32) decimal.Decimal {
+	switch m {
+	case RoundHalfUp:
+		return d.Round(scale)
+	case RoundDown:
+		return d.Truncate(scale)
+	default:
+		return d.RoundBank(scale)
+	}
+}
@@ -0,0 +1,82 @@
+package llmcostops
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecimal_JSONRoundTrip(t *testing.T) {
+	d, err := NewDecimalFromString("0.000003")
+	if err != nil {
+		t.Fatalf("NewDecimalFromString() error = %v", err)
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != "0.000003" {
+		t.Errorf("Marshal() = %s, want a bare JSON number 0.000003", data)
+	}
+
+	var decoded Decimal
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !decoded.Equal(d.Decimal) {
+		t.Errorf("decoded = %s, want %s", decoded, d)
+	}
+}
+
+func TestDecimal_UnmarshalQuotedString(t *testing.T) {
+	var d Decimal
+	if err := json.Unmarshal([]byte(`"1.50"`), &d); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if d.String() != "1.5" {
+		t.Errorf("got %s, want 1.5", d)
+	}
+}
+
+func TestDecimal_NoFloatDrift(t *testing.T) {
+	// The textbook float64 failure: 0.1 + 0.2 != 0.3. A decimal-backed sum
+	// of the same values must be exact.
+	a := MustDecimal("0.1")
+	b := MustDecimal("0.2")
+	sum := a.Add(b.Decimal)
+	if sum.String() != "0.3" {
+		t.Errorf("0.1 + 0.2 = %s, want 0.3", sum)
+	}
+}
+
+func TestDecimal_InvalidString(t *testing.T) {
+	if _, err := NewDecimalFromString("not-a-number"); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestRoundingMode_Round(t *testing.T) {
+	tests := []struct {
+		name  string
+		mode  RoundingMode
+		value string
+		scale int32
+		want  string
+	}{
+		{"half even rounds 2.5 to 2", RoundHalfEven, "2.5", 0, "2"},
+		{"half even rounds 3.5 to 4", RoundHalfEven, "3.5", 0, "4"},
+		{"half up rounds 2.5 to 3", RoundHalfUp, "2.5", 0, "3"},
+		{"down truncates 2.9", RoundDown, "2.9", 0, "2"},
+		{"down truncates negative toward zero", RoundDown, "-2.9", 0, "-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := MustDecimal(tt.value)
+			got := tt.mode.round(d.Decimal, tt.scale)
+			if got.String() != tt.want {
+				t.Errorf("round(%s) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package llmcostops
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// EmailDeliverer delivers a report as an email attachment via a caller
+// supplied send function, so the SDK doesn't take a dependency on any
+// particular mail provider.
+type EmailDeliverer struct {
+	// To is the recipient address.
+	To string
+
+	// Send is invoked with the recipient, the rendered subject, and the
+	// attachment bytes.
+	// Typically a thin wrapper around SES, SendGrid, SMTP, etc.
+	Send func(ctx context.Context, to, subject string, attachment []byte) error
+}
+
+// Deliver implements Deliverer.
+func (d *EmailDeliverer) Deliver(ctx context.Context, report *ScheduledReport, data []byte) error {
+	if d.Send == nil {
+		return fmt.Errorf("llmcostops: EmailDeliverer.Send is not set")
+	}
+	subject := fmt.Sprintf("Scheduled report: %s", report.Name)
+	return d.Send(ctx, d.To, subject, data)
+}
+
+// S3Deliverer uploads a report to an S3-compatible bucket via a caller
+// supplied upload function, avoiding a hard dependency on the AWS SDK.
+type S3Deliverer struct {
+	Bucket string
+	Prefix string
+
+	// Upload is invoked with the destination key and the report bytes.
+	Upload func(ctx context.Context, bucket, key string, data []byte) error
+}
+
+// Deliver implements Deliverer.
+func (d *S3Deliverer) Deliver(ctx context.Context, report *ScheduledReport, data []byte) error {
+	if d.Upload == nil {
+		return fmt.Errorf("llmcostops: S3Deliverer.Upload is not set")
+	}
+	key := fmt.Sprintf("%s%s.%s", d.Prefix, report.ID, report.Format)
+	return d.Upload(ctx, d.Bucket, key, data)
+}
+
+// WebhookDeliverer POSTs a report to a configured URL.
+type WebhookDeliverer struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// Deliver implements Deliverer.
+func (d *WebhookDeliverer) Deliver(ctx context.Context, report *ScheduledReport, data []byte) error {
+	client := d.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook delivery request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-Report-Id", report.ID)
+	req.Header.Set("X-Report-Name", report.Name)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook delivery failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook delivery returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SlackDeliverer posts a report link or snippet to a Slack incoming webhook.
+type SlackDeliverer struct {
+	WebhookURL string
+	HTTPClient *http.Client
+}
+
+// Deliver implements Deliverer. Since Slack webhooks don't accept arbitrary
+// binary attachments, large reports should be uploaded elsewhere first and
+// referenced by URL; this sends a simple notification with the report size.
+func (d *SlackDeliverer) Deliver(ctx context.Context, report *ScheduledReport, data []byte) error {
+	client := d.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	payload := fmt.Sprintf(`{"text":"Scheduled report %q is ready (%d bytes, format %s)"}`, report.Name, len(data), report.Format)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.WebhookURL, bytes.NewReader([]byte(payload)))
+	if err != nil {
+		return fmt.Errorf("failed to build Slack delivery request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("slack delivery failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("slack delivery returned status %d", resp.StatusCode)
+	}
+	return nil
+}
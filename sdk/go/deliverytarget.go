@@ -0,0 +1,292 @@
+package llmcostops
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DeliveryTarget is a typed destination for a ScheduledReport's output,
+// replacing the opaque ReportScheduleParams.DeliveryConfig map a caller
+// used to hand-assemble. Set ReportScheduleParams.Target to one of the
+// built-ins below (or a custom type registered via RegisterDeliveryTarget)
+// and ScheduleReport/UpdateScheduledReport validate and marshal it into
+// DeliveryMethod/DeliveryConfig before sending the request.
+type DeliveryTarget interface {
+	// Method returns the delivery_method discriminator the server expects,
+	// e.g. "s3" or "slack".
+	Method() string
+
+	// Validate checks the target's fields are complete and consistent.
+	Validate() error
+
+	// Config marshals the target into the delivery_config map shape the
+	// server expects.
+	Config() (map[string]interface{}, error)
+}
+
+// resolveTarget validates p.Target, if set, and overwrites
+// p.DeliveryMethod/p.DeliveryConfig with its Method()/Config(), so the two
+// stay in sync with whichever typed target the caller built.
+func (p *ReportScheduleParams) resolveTarget() error {
+	if p.Target == nil {
+		return nil
+	}
+	if err := p.Target.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	cfg, err := p.Target.Config()
+	if err != nil {
+		return fmt.Errorf("%w: encoding delivery target: %v", ErrBadRequest, err)
+	}
+	p.DeliveryMethod = p.Target.Method()
+	p.DeliveryConfig = cfg
+	return nil
+}
+
+// deliveryTargetConfig marshals v (a DeliveryTarget implementation) to its
+// delivery_config map form via its own json tags.
+func deliveryTargetConfig(v interface{}) (map[string]interface{}, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var cfg map[string]interface{}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// S3Delivery writes a scheduled report's output to an S3 bucket.
+type S3Delivery struct {
+	Bucket   string `json:"bucket"`
+	Region   string `json:"region"`
+	Prefix   string `json:"prefix,omitempty"`
+	KMSKeyID string `json:"kms_key_id,omitempty"`
+}
+
+func (d *S3Delivery) Method() string { return "s3" }
+
+func (d *S3Delivery) Validate() error {
+	if d.Bucket == "" {
+		return fmt.Errorf("s3 delivery: bucket is required")
+	}
+	if d.Region == "" {
+		return fmt.Errorf("s3 delivery: region is required")
+	}
+	return nil
+}
+
+func (d *S3Delivery) Config() (map[string]interface{}, error) { return deliveryTargetConfig(d) }
+
+// GCSDelivery writes a scheduled report's output to a Google Cloud Storage
+// bucket.
+type GCSDelivery struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix,omitempty"`
+}
+
+func (d *GCSDelivery) Method() string { return "gcs" }
+
+func (d *GCSDelivery) Validate() error {
+	if d.Bucket == "" {
+		return fmt.Errorf("gcs delivery: bucket is required")
+	}
+	return nil
+}
+
+func (d *GCSDelivery) Config() (map[string]interface{}, error) { return deliveryTargetConfig(d) }
+
+// AzureBlobDelivery writes a scheduled report's output to an Azure Blob
+// Storage container.
+type AzureBlobDelivery struct {
+	AccountURL string `json:"account_url"`
+	Container  string `json:"container"`
+	Prefix     string `json:"prefix,omitempty"`
+}
+
+func (d *AzureBlobDelivery) Method() string { return "azure_blob" }
+
+func (d *AzureBlobDelivery) Validate() error {
+	if d.AccountURL == "" {
+		return fmt.Errorf("azure blob delivery: account_url is required")
+	}
+	if d.Container == "" {
+		return fmt.Errorf("azure blob delivery: container is required")
+	}
+	return nil
+}
+
+func (d *AzureBlobDelivery) Config() (map[string]interface{}, error) { return deliveryTargetConfig(d) }
+
+// SFTPDelivery uploads a scheduled report's output over SFTP.
+type SFTPDelivery struct {
+	Host       string `json:"host"`
+	Port       int    `json:"port,omitempty"`
+	Path       string `json:"path"`
+	Username   string `json:"username"`
+	PrivateKey string `json:"private_key,omitempty"`
+}
+
+func (d *SFTPDelivery) Method() string { return "sftp" }
+
+func (d *SFTPDelivery) Validate() error {
+	if d.Host == "" {
+		return fmt.Errorf("sftp delivery: host is required")
+	}
+	if d.Path == "" {
+		return fmt.Errorf("sftp delivery: path is required")
+	}
+	if d.Username == "" {
+		return fmt.Errorf("sftp delivery: username is required")
+	}
+	return nil
+}
+
+func (d *SFTPDelivery) Config() (map[string]interface{}, error) { return deliveryTargetConfig(d) }
+
+// WebhookDelivery posts a scheduled report's output to URL, signing the
+// request body with Secret the same way WebhooksService signs event
+// deliveries.
+type WebhookDelivery struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+func (d *WebhookDelivery) Method() string { return "webhook" }
+
+func (d *WebhookDelivery) Validate() error {
+	if d.URL == "" {
+		return fmt.Errorf("webhook delivery: url is required")
+	}
+	if d.Secret == "" {
+		return fmt.Errorf("webhook delivery: secret is required")
+	}
+	return nil
+}
+
+func (d *WebhookDelivery) Config() (map[string]interface{}, error) { return deliveryTargetConfig(d) }
+
+// Sign computes the HMAC-SHA256 signature of payload delivered at ts over
+// the canonical string of ts's Unix seconds followed by the payload. This
+// differs from signWebhookDelivery's X-Event-Signature, whose canonical
+// string also includes the event ID and the receive window.
+func (d *WebhookDelivery) Sign(payload []byte, ts time.Time) string {
+	canonical := fmt.Sprintf("%d%s", ts.Unix(), payload)
+	mac := hmac.New(sha256.New, []byte(d.Secret))
+	mac.Write([]byte(canonical))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// EmailDelivery emails a scheduled report's output to Recipients.
+type EmailDelivery struct {
+	Recipients []string `json:"recipients"`
+	Subject    string   `json:"subject,omitempty"`
+}
+
+func (d *EmailDelivery) Method() string { return "email" }
+
+func (d *EmailDelivery) Validate() error {
+	if len(d.Recipients) == 0 {
+		return fmt.Errorf("email delivery: at least one recipient is required")
+	}
+	return nil
+}
+
+func (d *EmailDelivery) Config() (map[string]interface{}, error) { return deliveryTargetConfig(d) }
+
+// SlackDelivery posts a scheduled report's output to a Slack incoming
+// webhook.
+type SlackDelivery struct {
+	WebhookURL string `json:"webhook_url"`
+	Channel    string `json:"channel,omitempty"`
+}
+
+func (d *SlackDelivery) Method() string { return "slack" }
+
+func (d *SlackDelivery) Validate() error {
+	if d.WebhookURL == "" {
+		return fmt.Errorf("slack delivery: webhook_url is required")
+	}
+	return nil
+}
+
+func (d *SlackDelivery) Config() (map[string]interface{}, error) { return deliveryTargetConfig(d) }
+
+// deliveryTargetFactory constructs a blank DeliveryTarget to decode a
+// ScheduledReport's DeliveryConfig map into, keyed by its DeliveryMethod.
+type deliveryTargetFactory func() DeliveryTarget
+
+// builtinDeliveryTargets are the delivery methods this package decodes out
+// of the box; RegisterDeliveryTarget adds to or overrides them per-client.
+var builtinDeliveryTargets = map[string]deliveryTargetFactory{
+	"s3":         func() DeliveryTarget { return &S3Delivery{} },
+	"gcs":        func() DeliveryTarget { return &GCSDelivery{} },
+	"azure_blob": func() DeliveryTarget { return &AzureBlobDelivery{} },
+	"sftp":       func() DeliveryTarget { return &SFTPDelivery{} },
+	"webhook":    func() DeliveryTarget { return &WebhookDelivery{} },
+	"email":      func() DeliveryTarget { return &EmailDelivery{} },
+	"slack":      func() DeliveryTarget { return &SlackDelivery{} },
+}
+
+// deliveryTargets holds per-client RegisterDeliveryTarget overrides,
+// checked before builtinDeliveryTargets.
+type deliveryTargetRegistry struct {
+	mu      sync.Mutex
+	targets map[string]deliveryTargetFactory
+}
+
+// RegisterDeliveryTarget adds (or overrides) the delivery method
+// DecodeDeliveryTarget recognizes, so callers can plug custom delivery
+// backends beyond the built-ins (S3Delivery, GCSDelivery,
+// AzureBlobDelivery, SFTPDelivery, WebhookDelivery, EmailDelivery,
+// SlackDelivery). factory must return a new, zero-valued instance each
+// call.
+func (s *ExportService) RegisterDeliveryTarget(method string, factory func() DeliveryTarget) {
+	s.deliveryTargets.mu.Lock()
+	defer s.deliveryTargets.mu.Unlock()
+	if s.deliveryTargets.targets == nil {
+		s.deliveryTargets.targets = make(map[string]deliveryTargetFactory)
+	}
+	s.deliveryTargets.targets[method] = factory
+}
+
+func (s *ExportService) deliveryTargetFactory(method string) (deliveryTargetFactory, bool) {
+	s.deliveryTargets.mu.Lock()
+	factory, ok := s.deliveryTargets.targets[method]
+	s.deliveryTargets.mu.Unlock()
+	if ok {
+		return factory, true
+	}
+	factory, ok = builtinDeliveryTargets[method]
+	return factory, ok
+}
+
+// DecodeDeliveryTarget reconstructs the typed DeliveryTarget report's
+// DeliveryMethod/DeliveryConfig describe, for a method registered via
+// RegisterDeliveryTarget or one of the built-ins.
+func (s *ExportService) DecodeDeliveryTarget(report *ScheduledReport) (DeliveryTarget, error) {
+	if report == nil {
+		return nil, fmt.Errorf("%w: report cannot be nil", ErrBadRequest)
+	}
+
+	factory, ok := s.deliveryTargetFactory(report.DeliveryMethod)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown delivery method %q", ErrBadRequest, report.DeliveryMethod)
+	}
+
+	raw, err := json.Marshal(report.DeliveryConfig)
+	if err != nil {
+		return nil, fmt.Errorf("%w: encoding delivery_config: %v", ErrBadRequest, err)
+	}
+	target := factory()
+	if err := json.Unmarshal(raw, target); err != nil {
+		return nil, fmt.Errorf("%w: decoding delivery_config for %q: %v", ErrBadRequest, report.DeliveryMethod, err)
+	}
+	return target, nil
+}
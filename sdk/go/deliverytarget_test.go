@@ -0,0 +1,153 @@
+package llmcostops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestS3Delivery_ValidatesRequiredFields(t *testing.T) {
+	d := &S3Delivery{Bucket: "exports"}
+	if err := d.Validate(); err == nil {
+		t.Fatal("expected validation error for missing region")
+	}
+
+	d.Region = "us-east-1"
+	if err := d.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	cfg, err := d.Config()
+	if err != nil {
+		t.Fatalf("Config() error = %v", err)
+	}
+	if cfg["bucket"] != "exports" || cfg["region"] != "us-east-1" {
+		t.Errorf("Config() = %+v", cfg)
+	}
+}
+
+func TestWebhookDelivery_Sign_IsDeterministic(t *testing.T) {
+	d := &WebhookDelivery{URL: "https://example.com/hook", Secret: "s3cr3t"}
+	ts := time.Unix(1700000000, 0)
+	payload := []byte(`{"report_id":"r-1"}`)
+
+	sig1 := d.Sign(payload, ts)
+	sig2 := d.Sign(payload, ts)
+	if sig1 != sig2 {
+		t.Errorf("Sign() is not deterministic: %q != %q", sig1, sig2)
+	}
+
+	other := &WebhookDelivery{URL: d.URL, Secret: "different"}
+	if other.Sign(payload, ts) == sig1 {
+		t.Error("Sign() should differ with a different secret")
+	}
+}
+
+func TestReportScheduleParams_ResolveTarget(t *testing.T) {
+	params := &ReportScheduleParams{Target: &SlackDelivery{WebhookURL: "https://hooks.slack.test/abc"}}
+	if err := params.resolveTarget(); err != nil {
+		t.Fatalf("resolveTarget() error = %v", err)
+	}
+	if params.DeliveryMethod != "slack" {
+		t.Errorf("DeliveryMethod = %q, want slack", params.DeliveryMethod)
+	}
+	if params.DeliveryConfig["webhook_url"] != "https://hooks.slack.test/abc" {
+		t.Errorf("DeliveryConfig = %+v", params.DeliveryConfig)
+	}
+}
+
+func TestReportScheduleParams_ResolveTarget_PropagatesValidationError(t *testing.T) {
+	params := &ReportScheduleParams{Target: &SlackDelivery{}}
+	if err := params.resolveTarget(); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestExportService_ScheduleReport_UsesTarget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body ReportScheduleParams
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.DeliveryMethod != "s3" || body.DeliveryConfig["bucket"] != "exports" {
+			t.Errorf("request body = %+v", body)
+		}
+		json.NewEncoder(w).Encode(ScheduledReport{ID: "sched-1", DeliveryMethod: "s3"})
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	_, err := client.Export.ScheduleReport(context.Background(), &ReportScheduleParams{
+		Name:     "weekly-cost",
+		Schedule: "0 9 * * 1",
+		Target:   &S3Delivery{Bucket: "exports", Region: "us-east-1"},
+	})
+	if err != nil {
+		t.Fatalf("ScheduleReport() error = %v", err)
+	}
+}
+
+func TestExportService_DecodeDeliveryTarget_Builtin(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	report := &ScheduledReport{
+		DeliveryMethod: "s3",
+		DeliveryConfig: map[string]interface{}{"bucket": "exports", "region": "us-east-1"},
+	}
+
+	target, err := client.Export.DecodeDeliveryTarget(report)
+	if err != nil {
+		t.Fatalf("DecodeDeliveryTarget() error = %v", err)
+	}
+	s3, ok := target.(*S3Delivery)
+	if !ok {
+		t.Fatalf("DecodeDeliveryTarget() = %T, want *S3Delivery", target)
+	}
+	if s3.Bucket != "exports" || s3.Region != "us-east-1" {
+		t.Errorf("decoded target = %+v", s3)
+	}
+}
+
+type testDelivery struct {
+	Endpoint string `json:"endpoint"`
+}
+
+func (d *testDelivery) Method() string  { return "custom" }
+func (d *testDelivery) Validate() error { return nil }
+func (d *testDelivery) Config() (map[string]interface{}, error) {
+	return deliveryTargetConfig(d)
+}
+
+func TestExportService_RegisterDeliveryTarget_Custom(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	client.Export.RegisterDeliveryTarget("custom", func() DeliveryTarget { return &testDelivery{} })
+
+	report := &ScheduledReport{
+		DeliveryMethod: "custom",
+		DeliveryConfig: map[string]interface{}{"endpoint": "https://internal.example/reports"},
+	}
+
+	target, err := client.Export.DecodeDeliveryTarget(report)
+	if err != nil {
+		t.Fatalf("DecodeDeliveryTarget() error = %v", err)
+	}
+	custom, ok := target.(*testDelivery)
+	if !ok || custom.Endpoint != "https://internal.example/reports" {
+		t.Errorf("DecodeDeliveryTarget() = %+v", target)
+	}
+}
+
+func TestExportService_DecodeDeliveryTarget_UnknownMethod(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	if _, err := client.Export.DecodeDeliveryTarget(&ScheduledReport{DeliveryMethod: "carrier-pigeon"}); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
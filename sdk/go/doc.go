@@ -40,8 +40,8 @@ Create a client and start tracking costs:
 		pricing, err := client.Pricing.Add(ctx, &llmcostops.PricingAddParams{
 			Provider:              llmcostops.ProviderOpenAI,
 			Model:                 "gpt-4",
-			InputPricePerMillion:  10.0,
-			OutputPricePerMillion: 30.0,
+			InputPricePerMillion:  llmcostops.NewDecimalFromFloat(10.0),
+			OutputPricePerMillion: llmcostops.NewDecimalFromFloat(30.0),
 		})
 
 		// Ingest usage
@@ -28,8 +28,8 @@ func main() {
 	pricing, err := client.Pricing.Add(ctx, &llmcostops.PricingAddParams{
 		Provider:              llmcostops.ProviderOpenAI,
 		Model:                 "gpt-4",
-		InputPricePerMillion:  10.0,
-		OutputPricePerMillion: 30.0,
+		InputPricePerMillion:  llmcostops.NewDecimalFromFloat(10.0),
+		OutputPricePerMillion: llmcostops.NewDecimalFromFloat(30.0),
 		Currency:              llmcostops.CurrencyUSD,
 	})
 	if err != nil {
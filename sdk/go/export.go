@@ -6,11 +6,16 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 // ExportService handles export-related API operations
 type ExportService struct {
 	client *Client
+
+	// deliveryTargets holds RegisterDeliveryTarget overrides, consulted
+	// before builtinDeliveryTargets by DecodeDeliveryTarget.
+	deliveryTargets deliveryTargetRegistry
 }
 
 // ExportParams represents parameters for exporting data
@@ -22,6 +27,15 @@ type ExportParams struct {
 	Provider       Provider     `json:"provider,omitempty"`
 	Model          string       `json:"model,omitempty"`
 	IncludeHeaders bool         `json:"include_headers,omitempty"`
+
+	// Encryption, if set, requests the export's output be encrypted at
+	// rest before it's written to storage. See ExportEncryption.
+	Encryption *ExportEncryption `json:"encryption,omitempty"`
+
+	// SignedURL, if set, requests the server mint a signed download URL
+	// for the export's output as soon as it's ready, in addition to
+	// whatever GenerateSignedURL can mint later for the same export.
+	SignedURL *SignedURLOptions `json:"signed_url,omitempty"`
 }
 
 // ReportScheduleParams represents parameters for scheduling a report
@@ -35,6 +49,13 @@ type ReportScheduleParams struct {
 	DeliveryMethod string                 `json:"delivery_method"` // "email", "storage", "webhook"
 	DeliveryConfig map[string]interface{} `json:"delivery_config,omitempty"`
 	Enabled        bool                   `json:"enabled"`
+
+	// Target, if set, overrides DeliveryMethod/DeliveryConfig: ScheduleReport
+	// and UpdateScheduledReport validate it and marshal it into those two
+	// fields before sending the request, so callers can use a typed
+	// DeliveryTarget (S3Delivery, SlackDelivery, ...) instead of hand-building
+	// the delivery_config map.
+	Target DeliveryTarget `json:"-"`
 }
 
 // ScheduledReport represents a scheduled report
@@ -55,6 +76,117 @@ type ScheduledReport struct {
 	UpdatedAt      string                 `json:"updated_at"`
 }
 
+// ExportResumeToken identifies where to continue an interrupted
+// ExportStream download: Offset bytes already consumed by the caller, plus
+// the ETag the server sent with those bytes, so the server can tell
+// whether the underlying export result changed since and reject the
+// resume rather than splice mismatched data together.
+type ExportResumeToken struct {
+	Offset int64  `json:"offset"`
+	ETag   string `json:"etag"`
+}
+
+// ExportMetadata describes an ExportStream response: ContentLength is -1
+// if the server didn't send one (the caller can still stream it, just
+// without a progress total), and ETag identifies this export result for a
+// later ExportResumeToken.
+type ExportMetadata struct {
+	ContentLength int64
+	ETag          string
+}
+
+// ExportStreamOptions configures ExportStream.
+type ExportStreamOptions struct {
+	// Resume continues a previous download from Resume.Offset, sent as a
+	// "Range: bytes=Offset-" request header with "If-Range: Resume.ETag"
+	// so the server can reject the resume instead of serving a Range
+	// against data that no longer matches.
+	Resume *ExportResumeToken
+
+	// OnProgress, if set, is called after every chunk read from the
+	// response body with the cumulative bytes read so far and the total
+	// from ExportMetadata.ContentLength (-1 if the server didn't send a
+	// Content-Length). It runs synchronously on the caller's Read
+	// goroutine and must not block.
+	OnProgress func(bytesRead, total int64)
+}
+
+// ExportStream requests an export the same way Export does but returns the
+// response body directly instead of buffering it into memory, so the
+// caller can stream multi-gigabyte results. The returned ReadCloser must
+// be closed to release the underlying connection; it is canceled the
+// moment ctx is done. Pass opts.Resume to continue a download interrupted
+// after the caller had already read opts.Resume.Offset bytes.
+func (s *ExportService) ExportStream(ctx context.Context, params *ExportParams, opts *ExportStreamOptions) (io.ReadCloser, *ExportMetadata, error) {
+	if params == nil {
+		return nil, nil, fmt.Errorf("%w: params cannot be nil", ErrBadRequest)
+	}
+	if params.Format == "" {
+		return nil, nil, fmt.Errorf("%w: format is required", ErrBadRequest)
+	}
+
+	if params.Encryption != nil {
+		if err := params.Encryption.Validate(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	req, err := s.client.newRequest(http.MethodPost, "/api/v1/export", params)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "*/*")
+
+	if opts != nil && opts.Resume != nil {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", opts.Resume.Offset))
+		if opts.Resume.ETag != "" {
+			req.Header.Set("If-Range", opts.Resume.ETag)
+		}
+	}
+
+	resp, err := s.client.executeRequest(ctx, req.Clone(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, nil, s.client.checkResponseStatus(resp.StatusCode, body)
+	}
+
+	meta := &ExportMetadata{ContentLength: resp.ContentLength, ETag: resp.Header.Get("ETag")}
+
+	body := resp.Body
+	if opts != nil && opts.OnProgress != nil {
+		read := int64(0)
+		if resp.StatusCode == http.StatusPartialContent && opts.Resume != nil {
+			read = opts.Resume.Offset
+		}
+		body = &progressReadCloser{ReadCloser: body, read: read, total: meta.ContentLength, onProgress: opts.OnProgress}
+	}
+
+	return body, meta, nil
+}
+
+// progressReadCloser wraps a response body to report cumulative bytes read
+// to an ExportStreamOptions.OnProgress callback as the caller consumes it.
+type progressReadCloser struct {
+	io.ReadCloser
+	read       int64
+	total      int64
+	onProgress func(bytesRead, total int64)
+}
+
+func (p *progressReadCloser) Read(buf []byte) (int, error) {
+	n, err := p.ReadCloser.Read(buf)
+	if n > 0 {
+		p.read += int64(n)
+		p.onProgress(p.read, p.total)
+	}
+	return n, err
+}
+
 // Export exports data in the specified format and returns the raw data
 func (s *ExportService) Export(ctx context.Context, params *ExportParams) ([]byte, error) {
 	if params == nil {
@@ -65,6 +197,12 @@ func (s *ExportService) Export(ctx context.Context, params *ExportParams) ([]byt
 		return nil, fmt.Errorf("%w: format is required", ErrBadRequest)
 	}
 
+	if params.Encryption != nil {
+		if err := params.Encryption.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	req, err := s.client.newRequest(http.MethodPost, "/api/v1/export", params)
 	if err != nil {
 		return nil, err
@@ -85,7 +223,9 @@ func (s *ExportService) Export(ctx context.Context, params *ExportParams) ([]byt
 	}
 
 	// Set headers
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	if err := c.authenticator.Apply(ctx, req); err != nil {
+		return nil, fmt.Errorf("failed to authenticate request: %w", err)
+	}
 	req.Header.Set("User-Agent", UserAgent)
 	req.Header.Set("Accept", "*/*")
 
@@ -109,17 +249,99 @@ func (s *ExportService) Export(ctx context.Context, params *ExportParams) ([]byt
 	return data, nil
 }
 
-// ExportToWriter exports data and writes it to the provided writer
+// ExportToWriter streams an export straight to w via ExportStream and
+// io.Copy, so a multi-gigabyte result never sits fully in memory the way
+// Export's return value would.
 func (s *ExportService) ExportToWriter(ctx context.Context, params *ExportParams, w io.Writer) error {
-	data, err := s.Export(ctx, params)
+	body, _, err := s.ExportStream(ctx, params, nil)
 	if err != nil {
 		return err
 	}
+	defer body.Close()
 
-	_, err = w.Write(data)
+	_, err = io.Copy(w, body)
 	return err
 }
 
+// StreamExport streams an export in Format JSONL or CSV over a single
+// connection, returning the response body as it arrives rather than
+// buffering the whole result set the way Export does. The returned
+// exportStream additionally supports SetReadDeadline/SetWriteDeadline for
+// bounding how long a slow read may block; callers that don't need that
+// can simply treat it as an io.ReadCloser.
+func (s *ExportService) StreamExport(ctx context.Context, params *ExportParams) (io.ReadCloser, error) {
+	if params == nil {
+		return nil, fmt.Errorf("%w: params cannot be nil", ErrBadRequest)
+	}
+	if params.Format != FormatJSONL && params.Format != FormatCSV {
+		return nil, fmt.Errorf("%w: streaming export requires format jsonl or csv, got %q", ErrBadRequest, params.Format)
+	}
+
+	req, err := s.client.newRequest(http.MethodPost, "/api/v1/export/stream", params)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "*/*")
+
+	resp, err := s.client.executeRequest(ctx, req.Clone(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, s.client.checkResponseStatus(resp.StatusCode, body)
+	}
+
+	return &exportStream{body: resp.Body, readDeadline: newDeadlineTimer()}, nil
+}
+
+// exportStream wraps an in-flight export response body with a read
+// deadline, so StreamExport callers can bound how long a stalled server
+// may hold a Read call open without tearing down the whole connection.
+type exportStream struct {
+	body         io.ReadCloser
+	readDeadline *deadlineTimer
+}
+
+// Read implements io.Reader, racing the underlying read against the
+// configured read deadline.
+func (e *exportStream) Read(p []byte) (int, error) {
+	type result struct {
+		n   int
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		n, err := e.body.Read(p)
+		done <- result{n, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.n, r.err
+	case <-e.readDeadline.c():
+		return 0, fmt.Errorf("%w: export stream read deadline exceeded", ErrContextCanceled)
+	}
+}
+
+// Close implements io.Closer.
+func (e *exportStream) Close() error {
+	return e.body.Close()
+}
+
+// SetReadDeadline sets the deadline Read blocks against. A zero value
+// disables the deadline.
+func (e *exportStream) SetReadDeadline(t time.Time) {
+	e.readDeadline.set(t)
+}
+
+// SetWriteDeadline is a no-op: exportStream is read-only. It exists so
+// exportStream satisfies the same SetReadDeadline/SetWriteDeadline shape
+// as IngestSession and UsageStreamParams.ReadDeadline.
+func (e *exportStream) SetWriteDeadline(t time.Time) {}
+
 // ScheduleReport creates a scheduled report
 func (s *ExportService) ScheduleReport(ctx context.Context, params *ReportScheduleParams) (*ScheduledReport, error) {
 	if params == nil {
@@ -134,6 +356,14 @@ func (s *ExportService) ScheduleReport(ctx context.Context, params *ReportSchedu
 		return nil, fmt.Errorf("%w: schedule is required", ErrBadRequest)
 	}
 
+	if _, err := ParseSchedule(params.Schedule); err != nil {
+		return nil, err
+	}
+
+	if err := params.resolveTarget(); err != nil {
+		return nil, err
+	}
+
 	req, err := s.client.newRequest(http.MethodPost, "/api/v1/export/schedule", params)
 	if err != nil {
 		return nil, err
@@ -192,6 +422,16 @@ func (s *ExportService) UpdateScheduledReport(ctx context.Context, id string, pa
 		return nil, fmt.Errorf("%w: params cannot be nil", ErrBadRequest)
 	}
 
+	if params.Schedule != "" {
+		if _, err := ParseSchedule(params.Schedule); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := params.resolveTarget(); err != nil {
+		return nil, err
+	}
+
 	path := fmt.Sprintf("/api/v1/export/schedule/%s", url.PathEscape(id))
 	req, err := s.client.newRequest(http.MethodPut, path, params)
 	if err != nil {
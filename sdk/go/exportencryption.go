@@ -0,0 +1,177 @@
+package llmcostops
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/crypto/openpgp"
+)
+
+// ExportEncryptionMethod selects how an export's output is encrypted at
+// rest once the server writes it to storage.
+type ExportEncryptionMethod string
+
+const (
+	// EncryptionAES256 encrypts the output with a server-managed AES-256
+	// key.
+	EncryptionAES256 ExportEncryptionMethod = "aes256"
+	// EncryptionKMS encrypts the output with a caller-specified KMS key
+	// (or equivalent provider resource).
+	EncryptionKMS ExportEncryptionMethod = "kms"
+	// EncryptionPGP encrypts the output with a caller-supplied PGP public
+	// key, so only holders of the matching private key can read it.
+	EncryptionPGP ExportEncryptionMethod = "pgp"
+)
+
+// ExportEncryption requests an export's output be encrypted at rest.
+type ExportEncryption struct {
+	Method ExportEncryptionMethod `json:"method"`
+
+	// KeyARN identifies the KMS key (or other provider resource ID) to
+	// encrypt under. Required when Method is EncryptionKMS.
+	KeyARN string `json:"key_arn,omitempty"`
+
+	// PGPPublicKey is the ASCII-armored PGP public key to encrypt the
+	// output with. Required when Method is EncryptionPGP; decrypt the
+	// result with DecryptExportPGP and the matching private key.
+	PGPPublicKey string `json:"pgp_public_key,omitempty"`
+}
+
+// Validate checks that e carries the fields its Method requires.
+func (e *ExportEncryption) Validate() error {
+	switch e.Method {
+	case EncryptionAES256:
+		return nil
+	case EncryptionKMS:
+		if e.KeyARN == "" {
+			return fmt.Errorf("%w: key_arn is required for kms encryption", ErrBadRequest)
+		}
+		return nil
+	case EncryptionPGP:
+		if e.PGPPublicKey == "" {
+			return fmt.Errorf("%w: pgp_public_key is required for pgp encryption", ErrBadRequest)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown encryption method %q", ErrBadRequest, e.Method)
+	}
+}
+
+// SignedURLOptions configures GenerateSignedURL.
+type SignedURLOptions struct {
+	// TTL bounds how long the signed URL remains valid. The server applies
+	// its own default and maximum if TTL is zero or exceeds them.
+	TTL time.Duration `json:"ttl,omitempty"`
+
+	// AllowedIPs, if set, restricts which client IPs may use the URL.
+	AllowedIPs []string `json:"allowed_ips,omitempty"`
+
+	// OneTimeUse, if true, invalidates the URL after its first successful
+	// download.
+	OneTimeUse bool `json:"one_time_use,omitempty"`
+}
+
+// SignedURL is a time-bounded, credential-free download link for an
+// export's output.
+type SignedURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// GenerateSignedURL mints a signed download URL for the completed export
+// exportID (an ExportJob.ID), so the caller can hand it to a downstream
+// consumer without sharing their API key. opts may be nil to accept the
+// server's defaults.
+func (s *ExportService) GenerateSignedURL(ctx context.Context, exportID string, opts *SignedURLOptions) (*SignedURL, error) {
+	if exportID == "" {
+		return nil, fmt.Errorf("%w: exportID is required", ErrBadRequest)
+	}
+	if opts == nil {
+		opts = &SignedURLOptions{}
+	}
+
+	path := fmt.Sprintf("/api/v1/export/jobs/%s/signed-url", url.PathEscape(exportID))
+	req, err := s.client.newRequest(http.MethodPost, path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SignedURL
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DecryptExportAES256 decrypts data that was produced with
+// ExportEncryption{Method: EncryptionAES256}, where data is an
+// AES-256-GCM-sealed ciphertext with the nonce prepended. key must be the
+// 32-byte AES-256 key.
+func DecryptExportAES256(ctx context.Context, data []byte, key []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid AES-256 key: %v", ErrBadRequest, err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("constructing GCM cipher: %w", err)
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(data) < nonceSize {
+		return nil, fmt.Errorf("%w: ciphertext shorter than nonce", ErrBadRequest)
+	}
+	nonce, ciphertext := data[:nonceSize], data[nonceSize:]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: decrypting export: %v", ErrBadRequest, err)
+	}
+	return plaintext, nil
+}
+
+// DecryptExportPGP decrypts data that was produced with
+// ExportEncryption{Method: EncryptionPGP}, using the ASCII-armored
+// privateKey (matching the PGPPublicKey the export was encrypted with) and
+// its passphrase.
+func DecryptExportPGP(ctx context.Context, data []byte, privateKey string, passphrase string) ([]byte, error) {
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader([]byte(privateKey)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid PGP private key: %v", ErrBadRequest, err)
+	}
+
+	if passphrase != "" {
+		for _, entity := range keyring {
+			if entity.PrivateKey != nil && entity.PrivateKey.Encrypted {
+				if err := entity.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+					return nil, fmt.Errorf("%w: decrypting PGP private key: %v", ErrBadRequest, err)
+				}
+			}
+			for _, subkey := range entity.Subkeys {
+				if subkey.PrivateKey != nil && subkey.PrivateKey.Encrypted {
+					if err := subkey.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+						return nil, fmt.Errorf("%w: decrypting PGP subkey: %v", ErrBadRequest, err)
+					}
+				}
+			}
+		}
+	}
+
+	md, err := openpgp.ReadMessage(bytes.NewReader(data), keyring, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: decrypting PGP message: %v", ErrBadRequest, err)
+	}
+
+	plaintext, err := io.ReadAll(md.UnverifiedBody)
+	if err != nil {
+		return nil, fmt.Errorf("reading decrypted PGP message: %w", err)
+	}
+	return plaintext, nil
+}
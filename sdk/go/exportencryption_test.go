@@ -0,0 +1,178 @@
+package llmcostops
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+func TestExportEncryption_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		enc     ExportEncryption
+		wantErr bool
+	}{
+		{"aes256 ok", ExportEncryption{Method: EncryptionAES256}, false},
+		{"kms missing key arn", ExportEncryption{Method: EncryptionKMS}, true},
+		{"kms ok", ExportEncryption{Method: EncryptionKMS, KeyARN: "arn:aws:kms:us-east-1:1:key/abc"}, false},
+		{"pgp missing public key", ExportEncryption{Method: EncryptionPGP}, true},
+		{"pgp ok", ExportEncryption{Method: EncryptionPGP, PGPPublicKey: "-----BEGIN PGP PUBLIC KEY BLOCK-----"}, false},
+		{"unknown method", ExportEncryption{Method: "rot13"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.enc.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !isError(err, ErrBadRequest) {
+				t.Errorf("expected ErrBadRequest, got %v", err)
+			}
+		})
+	}
+}
+
+func TestExportService_Export_RejectsInvalidEncryption(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	_, err := client.Export.Export(context.Background(), &ExportParams{
+		Format:     FormatJSON,
+		Encryption: &ExportEncryption{Method: EncryptionKMS},
+	})
+	if !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestExportService_GenerateSignedURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/export/jobs/job-1/signed-url" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var body SignedURLOptions
+		json.NewDecoder(r.Body).Decode(&body)
+		if !body.OneTimeUse {
+			t.Errorf("request body = %+v", body)
+		}
+		json.NewEncoder(w).Encode(SignedURL{URL: "https://cdn.example.com/exports/job-1?sig=abc"})
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	signed, err := client.Export.GenerateSignedURL(context.Background(), "job-1", &SignedURLOptions{OneTimeUse: true})
+	if err != nil {
+		t.Fatalf("GenerateSignedURL() error = %v", err)
+	}
+	if signed.URL == "" {
+		t.Error("expected non-empty URL")
+	}
+}
+
+func TestExportService_GenerateSignedURL_RequiresExportID(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	if _, err := client.Export.GenerateSignedURL(context.Background(), "", nil); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestDecryptExportAES256_RoundTrip(t *testing.T) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatalf("rand.Read() error = %v", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatalf("aes.NewCipher() error = %v", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatalf("cipher.NewGCM() error = %v", err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		t.Fatalf("rand.Read() error = %v", err)
+	}
+	plaintext := []byte("cost,provider\n1.50,openai\n")
+	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
+
+	got, err := DecryptExportAES256(context.Background(), ciphertext, key)
+	if err != nil {
+		t.Fatalf("DecryptExportAES256() error = %v", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("DecryptExportAES256() = %q, want %q", got, plaintext)
+	}
+}
+
+func TestDecryptExportAES256_RejectsBadKey(t *testing.T) {
+	if _, err := DecryptExportAES256(context.Background(), []byte("short"), []byte("not-32-bytes")); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestDecryptExportPGP_RoundTrip(t *testing.T) {
+	entity, err := openpgp.NewEntity("exports", "", "exports@example.com", nil)
+	if err != nil {
+		t.Fatalf("openpgp.NewEntity() error = %v", err)
+	}
+
+	var publicKeyBuf bytes.Buffer
+	armorWriter, err := armor.Encode(&publicKeyBuf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatalf("armor.Encode() error = %v", err)
+	}
+	if err := entity.Serialize(armorWriter); err != nil {
+		t.Fatalf("entity.Serialize() error = %v", err)
+	}
+	armorWriter.Close()
+
+	var privateKeyBuf bytes.Buffer
+	privArmorWriter, err := armor.Encode(&privateKeyBuf, openpgp.PrivateKeyType, nil)
+	if err != nil {
+		t.Fatalf("armor.Encode() error = %v", err)
+	}
+	if err := entity.SerializePrivate(privArmorWriter, nil); err != nil {
+		t.Fatalf("entity.SerializePrivate() error = %v", err)
+	}
+	privArmorWriter.Close()
+
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(publicKeyBuf.Bytes()))
+	if err != nil {
+		t.Fatalf("openpgp.ReadArmoredKeyRing() error = %v", err)
+	}
+
+	var ciphertext bytes.Buffer
+	w, err := openpgp.Encrypt(&ciphertext, keyring, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("openpgp.Encrypt() error = %v", err)
+	}
+	plaintext := []byte("cost,provider\n1.50,openai\n")
+	if _, err := w.Write(plaintext); err != nil {
+		t.Fatalf("w.Write() error = %v", err)
+	}
+	w.Close()
+
+	got, err := DecryptExportPGP(context.Background(), ciphertext.Bytes(), privateKeyBuf.String(), "")
+	if err != nil {
+		t.Fatalf("DecryptExportPGP() error = %v", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("DecryptExportPGP() = %q, want %q", got, plaintext)
+	}
+}
@@ -0,0 +1,263 @@
+package llmcostops
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ExportJobStatus is the lifecycle state of an asynchronous export job.
+type ExportJobStatus string
+
+const (
+	ExportJobPending   ExportJobStatus = "pending"
+	ExportJobRunning   ExportJobStatus = "running"
+	ExportJobSucceeded ExportJobStatus = "succeeded"
+	ExportJobFailed    ExportJobStatus = "failed"
+	ExportJobCancelled ExportJobStatus = "cancelled"
+)
+
+// terminal reports whether status is one WaitForExportJob should stop
+// polling at.
+func (status ExportJobStatus) terminal() bool {
+	switch status {
+	case ExportJobSucceeded, ExportJobFailed, ExportJobCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// ExportJob is an asynchronous export's current state, for result sets too
+// large for ExportStream's single-connection download.
+type ExportJob struct {
+	ID        string          `json:"id"`
+	Status    ExportJobStatus `json:"status"`
+	Progress  float64         `json:"progress"` // 0-100
+	RowCount  int64           `json:"row_count"`
+	OutputURL string          `json:"output_url,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	ExpiresAt *time.Time      `json:"expires_at,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// ExportJobFilter narrows ListExportJobs.
+type ExportJobFilter struct {
+	Status ExportJobStatus `json:"status,omitempty"`
+	PaginationParams
+}
+
+// defaultExportJobPollInterval and defaultExportJobMaxPollInterval are the
+// starting and capped backoff an ExportJobWaiter uses when the caller
+// doesn't set its own via SetPollInterval.
+const (
+	defaultExportJobPollInterval    = time.Second
+	defaultExportJobMaxPollInterval = 30 * time.Second
+)
+
+// StartExportJob starts an asynchronous export and returns its initial
+// ExportJob state; poll it with GetExportJob or block on it with
+// WaitForExportJob.
+func (s *ExportService) StartExportJob(ctx context.Context, params *ExportParams) (*ExportJob, error) {
+	if params == nil {
+		return nil, fmt.Errorf("%w: params cannot be nil", ErrBadRequest)
+	}
+	if params.Format == "" {
+		return nil, fmt.Errorf("%w: format is required", ErrBadRequest)
+	}
+
+	if params.Encryption != nil {
+		if err := params.Encryption.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := s.client.newRequest(http.MethodPost, "/api/v1/export/jobs", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ExportJob
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetExportJob retrieves an export job's current state by ID.
+func (s *ExportService) GetExportJob(ctx context.Context, id string) (*ExportJob, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: id is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/export/jobs/%s", url.PathEscape(id))
+	req, err := s.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ExportJob
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CancelExportJob requests cancellation of a running export job.
+func (s *ExportService) CancelExportJob(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: id is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/export/jobs/%s/cancel", url.PathEscape(id))
+	req, err := s.client.newRequest(http.MethodPost, path, nil)
+	if err != nil {
+		return err
+	}
+	return s.client.do(ctx, req, nil)
+}
+
+// ListExportJobs lists export jobs matching filter, most recently created
+// first. filter may be nil.
+func (s *ExportService) ListExportJobs(ctx context.Context, filter *ExportJobFilter) ([]ExportJob, error) {
+	req, err := s.client.newRequest(http.MethodGet, "/api/v1/export/jobs", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter != nil {
+		q := req.URL.Query()
+		if filter.Status != "" {
+			q.Set("status", string(filter.Status))
+		}
+		if filter.Page > 0 {
+			q.Set("page", fmt.Sprintf("%d", filter.Page))
+		}
+		if filter.PageSize > 0 {
+			q.Set("page_size", fmt.Sprintf("%d", filter.PageSize))
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	var result []ExportJob
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ExportJobWaitOptions configures WaitForExportJob.
+type ExportJobWaitOptions struct {
+	// PollInterval is the initial delay between GetExportJob polls,
+	// doubling after each poll up to MaxPollInterval. Defaults to
+	// defaultExportJobPollInterval/defaultExportJobMaxPollInterval.
+	PollInterval    time.Duration
+	MaxPollInterval time.Duration
+
+	// Deadline, if set, bounds the overall wait independently of ctx; see
+	// ExportJobWaiter.SetDeadline.
+	Deadline time.Time
+}
+
+// WaitForExportJob polls GetExportJob with exponential backoff until job
+// id reaches a terminal status (succeeded, failed, or cancelled), ctx is
+// canceled, or opts.Deadline elapses. It is sugar for NewExportJobWaiter
+// plus Wait for callers that don't need to hold onto the waiter handle.
+func (s *ExportService) WaitForExportJob(ctx context.Context, id string, opts *ExportJobWaitOptions) (*ExportJob, error) {
+	waiter := s.NewExportJobWaiter(id)
+	if opts != nil {
+		if opts.PollInterval > 0 || opts.MaxPollInterval > 0 {
+			waiter.SetPollInterval(opts.PollInterval, opts.MaxPollInterval)
+		}
+		if !opts.Deadline.IsZero() {
+			waiter.SetDeadline(opts.Deadline)
+		}
+	}
+	return waiter.Wait(ctx)
+}
+
+// ExportJobWaiter polls an export job to completion, mirroring the
+// deadlineTimer pattern exportStream/IngestSession use for
+// SetReadDeadline/SetWriteDeadline: SetDeadline arms an independent
+// deadline a caller can bound the wait by without touching ctx, and Wait
+// selects on it alongside ctx.Done() and the next poll.
+type ExportJobWaiter struct {
+	service *ExportService
+	id      string
+
+	mu          sync.Mutex
+	minInterval time.Duration
+	maxInterval time.Duration
+
+	deadline *deadlineTimer
+}
+
+// NewExportJobWaiter returns a waiter for job id with the default poll
+// interval and no deadline; configure it with SetPollInterval/SetDeadline
+// before calling Wait.
+func (s *ExportService) NewExportJobWaiter(id string) *ExportJobWaiter {
+	return &ExportJobWaiter{
+		service:     s,
+		id:          id,
+		minInterval: defaultExportJobPollInterval,
+		maxInterval: defaultExportJobMaxPollInterval,
+		deadline:    newDeadlineTimer(),
+	}
+}
+
+// SetPollInterval overrides the initial and capped backoff between polls.
+// A zero value leaves the corresponding bound unchanged.
+func (w *ExportJobWaiter) SetPollInterval(min, max time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if min > 0 {
+		w.minInterval = min
+	}
+	if max > 0 {
+		w.maxInterval = max
+	}
+}
+
+// SetDeadline arms a deadline that unblocks Wait once it passes,
+// independently of ctx. A zero value disarms it.
+func (w *ExportJobWaiter) SetDeadline(t time.Time) {
+	w.deadline.set(t)
+}
+
+// Wait polls GetExportJob with full-jitter exponential backoff until the
+// job reaches a terminal status, ctx is canceled, or the waiter's deadline
+// elapses.
+func (w *ExportJobWaiter) Wait(ctx context.Context) (*ExportJob, error) {
+	w.mu.Lock()
+	policy := RetryPolicy{
+		MinRetryDelay: w.minInterval,
+		MaxRetryDelay: w.maxInterval,
+		Multiplier:    2,
+		Jitter:        true,
+	}
+	w.mu.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		job, err := w.service.GetExportJob(ctx, w.id)
+		if err != nil {
+			return nil, err
+		}
+		if job.Status.terminal() {
+			return job, nil
+		}
+
+		timer := time.NewTimer(policy.delay(attempt, 0))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%w: %v", ErrContextCanceled, ctx.Err())
+		case <-w.deadline.c():
+			timer.Stop()
+			return nil, fmt.Errorf("%w: export job wait deadline exceeded", ErrContextCanceled)
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package llmcostops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestExportService_StartExportJob(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/export/jobs" || r.Method != http.MethodPost {
+			t.Errorf("Expected POST /api/v1/export/jobs, got %s %s", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(ExportJob{ID: "job-1", Status: ExportJobPending})
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	job, err := client.Export.StartExportJob(context.Background(), &ExportParams{Format: FormatCSV})
+	if err != nil {
+		t.Fatalf("StartExportJob() error = %v", err)
+	}
+	if job.ID != "job-1" || job.Status != ExportJobPending {
+		t.Errorf("StartExportJob() = %+v", job)
+	}
+}
+
+func TestExportService_WaitForExportJob_PollsUntilTerminal(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		status := ExportJobRunning
+		if calls >= 3 {
+			status = ExportJobSucceeded
+		}
+		json.NewEncoder(w).Encode(ExportJob{ID: "job-1", Status: status, Progress: 100})
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	job, err := client.Export.WaitForExportJob(context.Background(), "job-1", &ExportJobWaitOptions{
+		PollInterval: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("WaitForExportJob() error = %v", err)
+	}
+	if job.Status != ExportJobSucceeded {
+		t.Errorf("Status = %s, want %s", job.Status, ExportJobSucceeded)
+	}
+	if calls < 3 {
+		t.Errorf("expected at least 3 polls, got %d", calls)
+	}
+}
+
+func TestExportService_WaitForExportJob_Deadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(ExportJob{ID: "job-1", Status: ExportJobRunning})
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	waiter := client.Export.NewExportJobWaiter("job-1")
+	waiter.SetPollInterval(time.Millisecond, time.Millisecond)
+	waiter.SetDeadline(time.Now().Add(20 * time.Millisecond))
+
+	if _, err := waiter.Wait(context.Background()); !isError(err, ErrContextCanceled) {
+		t.Errorf("expected ErrContextCanceled, got %v", err)
+	}
+}
+
+func TestExportService_CancelExportJob_RequiresID(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	if err := client.Export.CancelExportJob(context.Background(), ""); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestExportService_ListExportJobs_FiltersByStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("status"); got != string(ExportJobFailed) {
+			t.Errorf("status query = %q, want %q", got, ExportJobFailed)
+		}
+		json.NewEncoder(w).Encode([]ExportJob{{ID: "job-1", Status: ExportJobFailed}})
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	jobs, err := client.Export.ListExportJobs(context.Background(), &ExportJobFilter{Status: ExportJobFailed})
+	if err != nil {
+		t.Fatalf("ListExportJobs() error = %v", err)
+	}
+	if len(jobs) != 1 || jobs[0].Status != ExportJobFailed {
+		t.Errorf("ListExportJobs() = %+v", jobs)
+	}
+}
@@ -0,0 +1,116 @@
+package llmcostops
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// DefaultForecastAlpha is the smoothing factor BudgetForecaster uses when
+// none is given to NewBudgetForecasterWithAlpha: higher weights recent
+// buckets more heavily, which suits cost data's tendency to shift level
+// abruptly (a new project onboarding, a model swap) rather than drift
+// smoothly.
+const DefaultForecastAlpha = 0.3
+
+// windowBucketCount returns how many daily buckets a BudgetWindow spans,
+// the granularity Forecast expects its history argument to be bucketed at.
+func windowBucketCount(w BudgetWindow) int {
+	switch w {
+	case BudgetWindowDaily:
+		return 1
+	case BudgetWindowWeekly:
+		return 7
+	case BudgetWindowMonthly:
+		return 30
+	default:
+		return 30
+	}
+}
+
+// BudgetForecast projects a BudgetRule's spend through the end of its
+// window.
+type BudgetForecast struct {
+	RuleID         string
+	Window         BudgetWindow
+	ObservedSpend  float64
+	ProjectedSpend float64
+	Limit          float64
+	SoftThreshold  float64
+	HardThreshold  float64
+
+	// WillBreachSoft/WillBreachHard report whether ProjectedSpend is
+	// expected to cross Limit*SoftThreshold / Limit*HardThreshold before
+	// the window closes.
+	WillBreachSoft bool
+	WillBreachHard bool
+}
+
+// BudgetForecaster projects a BudgetRule's spend forward from daily
+// CostSummary history using an exponentially-weighted moving average,
+// so a caller can tell whether a budget is on track to breach its
+// SoftThreshold or HardThreshold before its window closes rather than
+// only finding out once it already has.
+type BudgetForecaster struct {
+	alpha float64
+}
+
+// NewBudgetForecaster constructs a BudgetForecaster using
+// DefaultForecastAlpha.
+func NewBudgetForecaster() *BudgetForecaster {
+	return &BudgetForecaster{alpha: DefaultForecastAlpha}
+}
+
+// NewBudgetForecasterWithAlpha constructs a BudgetForecaster with a custom
+// smoothing factor in (0, 1]; values closer to 1 track the most recent
+// bucket more closely, values closer to 0 smooth over more history.
+func NewBudgetForecasterWithAlpha(alpha float64) *BudgetForecaster {
+	if alpha <= 0 || alpha > 1 {
+		alpha = DefaultForecastAlpha
+	}
+	return &BudgetForecaster{alpha: alpha}
+}
+
+// Forecast projects rule's spend through the end of its Window, given
+// history: one CostSummary per elapsed day of the window so far, oldest
+// first, scoped to rule.Filters by the caller (e.g. via CostService.Summary
+// called once per day). It computes an EWMA over history's TotalCost to
+// estimate the daily run rate, then extrapolates that rate across the
+// window's remaining days to project total spend.
+func (f *BudgetForecaster) Forecast(rule BudgetRule, history []CostSummary) (*BudgetForecast, error) {
+	if len(history) == 0 {
+		return nil, fmt.Errorf("%w: history must have at least one bucket", ErrBadRequest)
+	}
+
+	var observed float64
+	var ewma float64
+	for i, bucket := range history {
+		cost, err := strconv.ParseFloat(bucket.TotalCost, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: parsing bucket total_cost %q: %v", ErrServerError, bucket.TotalCost, err)
+		}
+		observed += cost
+		if i == 0 {
+			ewma = cost
+		} else {
+			ewma = f.alpha*cost + (1-f.alpha)*ewma
+		}
+	}
+
+	remaining := windowBucketCount(rule.Window) - len(history)
+	if remaining < 0 {
+		remaining = 0
+	}
+	projected := observed + ewma*float64(remaining)
+
+	return &BudgetForecast{
+		RuleID:         rule.ID,
+		Window:         rule.Window,
+		ObservedSpend:  observed,
+		ProjectedSpend: projected,
+		Limit:          rule.Limit,
+		SoftThreshold:  rule.SoftThreshold,
+		HardThreshold:  rule.HardThreshold,
+		WillBreachSoft: rule.SoftThreshold > 0 && projected >= rule.Limit*rule.SoftThreshold,
+		WillBreachHard: rule.HardThreshold > 0 && projected >= rule.Limit*rule.HardThreshold,
+	}, nil
+}
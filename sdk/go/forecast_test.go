@@ -0,0 +1,69 @@
+package llmcostops
+
+import "testing"
+
+func costSummaryWithTotal(total string) CostSummary {
+	return CostSummary{TotalCost: total}
+}
+
+func TestBudgetForecaster_Forecast(t *testing.T) {
+	rule := BudgetRule{
+		ID:            "rule-1",
+		Window:        BudgetWindowMonthly,
+		Limit:         300,
+		SoftThreshold: 0.8,
+		HardThreshold: 1.0,
+	}
+	history := []CostSummary{
+		costSummaryWithTotal("10"),
+		costSummaryWithTotal("10"),
+		costSummaryWithTotal("10"),
+	}
+
+	f := NewBudgetForecaster()
+	got, err := f.Forecast(rule, history)
+	if err != nil {
+		t.Fatalf("Forecast() error = %v", err)
+	}
+	if got.ObservedSpend != 30 {
+		t.Errorf("ObservedSpend = %v, want 30", got.ObservedSpend)
+	}
+	// 27 remaining daily buckets at a steady $10/day run rate project well
+	// past both thresholds.
+	if !got.WillBreachSoft || !got.WillBreachHard {
+		t.Errorf("WillBreachSoft = %v, WillBreachHard = %v, want both true", got.WillBreachSoft, got.WillBreachHard)
+	}
+}
+
+func TestBudgetForecaster_NoBreachUnderLimit(t *testing.T) {
+	rule := BudgetRule{
+		ID:            "rule-2",
+		Window:        BudgetWindowDaily,
+		Limit:         100,
+		SoftThreshold: 0.8,
+		HardThreshold: 1.0,
+	}
+	history := []CostSummary{costSummaryWithTotal("5")}
+
+	got, err := NewBudgetForecaster().Forecast(rule, history)
+	if err != nil {
+		t.Fatalf("Forecast() error = %v", err)
+	}
+	if got.WillBreachSoft || got.WillBreachHard {
+		t.Errorf("WillBreachSoft = %v, WillBreachHard = %v, want both false", got.WillBreachSoft, got.WillBreachHard)
+	}
+}
+
+func TestBudgetForecaster_RejectsEmptyHistory(t *testing.T) {
+	rule := BudgetRule{ID: "rule-3", Window: BudgetWindowDaily, Limit: 100}
+	if _, err := NewBudgetForecaster().Forecast(rule, nil); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestNewBudgetForecasterWithAlpha_RejectsOutOfRange(t *testing.T) {
+	f := NewBudgetForecasterWithAlpha(1.5)
+	if f.alpha != DefaultForecastAlpha {
+		t.Errorf("alpha = %v, want fallback to DefaultForecastAlpha", f.alpha)
+	}
+}
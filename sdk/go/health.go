@@ -1,7 +1,11 @@
 package llmcostops
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 )
 
@@ -35,12 +39,100 @@ func (s *HealthService) Live(ctx context.Context) error {
 	return s.client.do(ctx, req, nil)
 }
 
-// Ready checks if the service is ready to accept traffic (readiness probe)
-func (s *HealthService) Ready(ctx context.Context) error {
+// ReadyOpts configures HealthService.Ready.
+type ReadyOpts struct {
+	// RequireComponents names components that must be ComponentHealthy for
+	// Ready to succeed, in addition to the server's own overall readiness
+	// determination. Empty means "ready" is all-or-nothing, matching the
+	// prior behavior.
+	RequireComponents []string
+}
+
+// Ready checks if the service is ready to accept traffic (readiness probe).
+// When opts.RequireComponents is set, Ready also fetches the full
+// HealthStatus and fails if any named component is not ComponentHealthy —
+// e.g. a Kubernetes readiness probe can require that "pricing_catalog" is
+// loaded before the pod accepts traffic.
+func (s *HealthService) Ready(ctx context.Context, opts ...ReadyOpts) error {
 	req, err := s.client.newRequest(http.MethodGet, "/health/ready", nil)
 	if err != nil {
 		return err
 	}
+	if err := s.client.do(ctx, req, nil); err != nil {
+		return err
+	}
 
-	return s.client.do(ctx, req, nil)
+	if len(opts) == 0 || len(opts[0].RequireComponents) == 0 {
+		return nil
+	}
+
+	status, err := s.Check(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range opts[0].RequireComponents {
+		comp, ok := status.Components[name]
+		if !ok {
+			return fmt.Errorf("%w: component %q not reported", ErrServerError, name)
+		}
+		if comp.Status != ComponentHealthy {
+			return fmt.Errorf("%w: component %q is %s: %s", ErrServerError, name, comp.Status, comp.Message)
+		}
+	}
+
+	return nil
+}
+
+// Watch server-side-streams HealthStatus changes via chunked
+// newline-delimited JSON, so long-running sidecars can react to backend
+// degradation (e.g. switch an async ingester into local-buffer mode). The
+// returned channel is closed when ctx is canceled or the stream ends.
+func (s *HealthService) Watch(ctx context.Context) (<-chan HealthStatus, error) {
+	u := *s.client.baseURL
+	u.Path = "/health/watch"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if err := s.client.authenticator.Apply(ctx, req); err != nil {
+		return nil, fmt.Errorf("failed to authenticate request: %w", err)
+	}
+	req.Header.Set("Accept", "application/x-ndjson")
+
+	resp, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open health watch stream: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("%w: health watch returned status %d", ErrServerError, resp.StatusCode)
+	}
+
+	statuses := make(chan HealthStatus)
+	go func() {
+		defer close(statuses)
+		defer resp.Body.Close()
+
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			var status HealthStatus
+			if err := json.Unmarshal(line, &status); err != nil {
+				continue
+			}
+			select {
+			case statuses <- status:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return statuses, nil
 }
@@ -0,0 +1,310 @@
+package llmcostops
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WireFormat selects the request encoding used by a UsageIngester. JSON is
+// the only encoding currently implemented; the type exists so a future
+// lower-overhead encoding (e.g. snappy-compressed protobuf, mirroring
+// Prometheus remote-write) can be added without an IngesterConfig break.
+type WireFormat string
+
+const (
+	// WireJSON sends one JSON array per flush (the default).
+	WireJSON WireFormat = "json"
+)
+
+// IngesterConfig configures a UsageIngester, modelled on the Prometheus
+// remote-write queue manager.
+type IngesterConfig struct {
+	// MinShards/MaxShards bound how many per-shard queues the ingester
+	// runs; it does not currently auto-scale between them, but the fields
+	// are kept symmetric with Prometheus's remote-write config for
+	// familiarity. MaxShards is the number actually started. Defaults:
+	// MinShards=1, MaxShards=4.
+	MinShards int
+	MaxShards int
+
+	// Capacity bounds how many records a single shard buffers before
+	// Enqueue starts dropping. Defaults to 2500.
+	Capacity int
+
+	// MaxSamplesPerSend caps how many records go out in a single flush.
+	// Defaults to 500.
+	MaxSamplesPerSend int
+
+	// BatchSendDeadline is the period of each shard's flush timer, which
+	// sends whatever the shard has buffered and restarts after every flush,
+	// so a buffered record waits at most this long. Defaults to 5s.
+	BatchSendDeadline time.Duration
+
+	// MinBackoff/MaxBackoff bound the exponential backoff applied after a
+	// 5xx/429 response. Defaults: 100ms / 30s.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Wire selects the request encoding. Defaults to WireJSON.
+	Wire WireFormat
+}
+
+func (c IngesterConfig) withDefaults() IngesterConfig {
+	if c.MinShards <= 0 {
+		c.MinShards = 1
+	}
+	if c.MaxShards <= 0 {
+		c.MaxShards = 4
+	}
+	if c.Capacity <= 0 {
+		c.Capacity = 2500
+	}
+	if c.MaxSamplesPerSend <= 0 {
+		c.MaxSamplesPerSend = 500
+	}
+	if c.BatchSendDeadline <= 0 {
+		c.BatchSendDeadline = 5 * time.Second
+	}
+	if c.MinBackoff <= 0 {
+		c.MinBackoff = 100 * time.Millisecond
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = 30 * time.Second
+	}
+	if c.Wire == "" {
+		c.Wire = WireJSON
+	}
+	return c
+}
+
+// IngesterStats reports cumulative counters for a UsageIngester.
+type IngesterStats struct {
+	Enqueued      int64
+	Sent          int64
+	Dropped       int64
+	Retried       int64
+	ShardsRunning int
+}
+
+// UsageIngester is a high-throughput, sharded async batcher for
+// UsageRecords, for sidecars emitting per-request usage at a rate where a
+// synchronous POST per call would be prohibitively expensive. Construct one
+// with UsageService.NewIngester.
+type UsageIngester struct {
+	client *Client
+	config IngesterConfig
+
+	shards []*ingesterShard
+
+	enqueued atomic.Int64
+	sent     atomic.Int64
+	dropped  atomic.Int64
+	retried  atomic.Int64
+}
+
+// NewIngester constructs and starts a UsageIngester backed by this service's
+// client.
+func (s *UsageService) NewIngester(config IngesterConfig) *UsageIngester {
+	config = config.withDefaults()
+
+	ing := &UsageIngester{client: s.client, config: config}
+	ing.shards = make([]*ingesterShard, config.MaxShards)
+	for i := range ing.shards {
+		ing.shards[i] = newIngesterShard(ing, i)
+	}
+	return ing
+}
+
+// Enqueue adds a single record to the ingester without blocking. If the
+// assigned shard's queue is full, the record is dropped and the Dropped
+// counter is incremented.
+func (ing *UsageIngester) Enqueue(rec UsageRecord) {
+	shard := ing.shards[ing.shardFor(rec)]
+	select {
+	case shard.buf <- rec:
+		ing.enqueued.Add(1)
+	default:
+		ing.dropped.Add(1)
+	}
+}
+
+func (ing *UsageIngester) shardFor(rec UsageRecord) int {
+	h := fnv32(rec.OrganizationID + "/" + rec.ProjectID)
+	return int(h) % len(ing.shards)
+}
+
+// Stats returns a snapshot of cumulative ingester counters.
+func (ing *UsageIngester) Stats() IngesterStats {
+	running := 0
+	for _, sh := range ing.shards {
+		if sh.running.Load() {
+			running++
+		}
+	}
+	return IngesterStats{
+		Enqueued:      ing.enqueued.Load(),
+		Sent:          ing.sent.Load(),
+		Dropped:       ing.dropped.Load(),
+		Retried:       ing.retried.Load(),
+		ShardsRunning: running,
+	}
+}
+
+// Shutdown stops all shards, draining their queues up to ctx's deadline.
+func (ing *UsageIngester) Shutdown(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for _, sh := range ing.shards {
+		wg.Add(1)
+		go func(sh *ingesterShard) {
+			defer wg.Done()
+			sh.drainAndStop(ctx)
+		}(sh)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ingesterShard owns one in-memory queue and flush loop.
+type ingesterShard struct {
+	ing     *UsageIngester
+	index   int
+	buf     chan UsageRecord
+	running atomic.Bool
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+func newIngesterShard(ing *UsageIngester, index int) *ingesterShard {
+	sh := &ingesterShard{
+		ing:     ing,
+		index:   index,
+		buf:     make(chan UsageRecord, ing.config.Capacity),
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	sh.running.Store(true)
+	go sh.loop()
+	return sh
+}
+
+func (sh *ingesterShard) loop() {
+	defer close(sh.stopped)
+	defer sh.running.Store(false)
+
+	batch := make([]UsageRecord, 0, sh.ing.config.MaxSamplesPerSend)
+	deadline := time.NewTimer(sh.ing.config.BatchSendDeadline)
+	defer deadline.Stop()
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		sh.send(batch)
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case rec := <-sh.buf:
+			batch = append(batch, rec)
+			if len(batch) >= sh.ing.config.MaxSamplesPerSend {
+				flush()
+				deadline.Reset(sh.ing.config.BatchSendDeadline)
+			}
+		case <-deadline.C:
+			flush()
+			deadline.Reset(sh.ing.config.BatchSendDeadline)
+		case <-sh.stop:
+			for {
+				select {
+				case rec := <-sh.buf:
+					batch = append(batch, rec)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (sh *ingesterShard) drainAndStop(ctx context.Context) {
+	close(sh.stop)
+	select {
+	case <-sh.stopped:
+	case <-ctx.Done():
+	}
+}
+
+// send POSTs a batch, retrying on 5xx/429 with exponential backoff and
+// jitter, honoring Retry-After when present.
+func (sh *ingesterShard) send(batch []UsageRecord) {
+	cfg := sh.ing.config
+	backoff := cfg.MinBackoff
+
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err := sh.post(ctx, batch)
+		cancel()
+
+		if err == nil {
+			sh.ing.sent.Add(int64(len(batch)))
+			return
+		}
+
+		sh.ing.retried.Add(1)
+
+		// The underlying client.do already honors Retry-After itself; this
+		// backoff only covers retries the ingester initiates on top of that
+		// (e.g. after client.do exhausts its own retry budget).
+		time.Sleep(backoff + time.Duration(rand.Int63n(int64(backoff)+1)))
+
+		backoff *= 2
+		if backoff > cfg.MaxBackoff {
+			backoff = cfg.MaxBackoff
+		}
+
+		if attempt >= 10 {
+			sh.ing.dropped.Add(int64(len(batch)))
+			return
+		}
+	}
+}
+
+// post sends a single ingest attempt for batch.
+func (sh *ingesterShard) post(ctx context.Context, batch []UsageRecord) error {
+	req, err := sh.ing.client.newRequest(http.MethodPost, "/api/v1/usage/ingest", &UsageIngestParams{Records: batch})
+	if err != nil {
+		return err
+	}
+
+	return sh.ing.client.do(ctx, req, nil)
+}
+
+func fnv32(s string) uint32 {
+	const (
+		offset32 = 2166136261
+		prime32  = 16777619
+	)
+	h := uint32(offset32)
+	for i := 0; i < len(s); i++ {
+		h *= prime32
+		h ^= uint32(s[i])
+	}
+	return h
+}
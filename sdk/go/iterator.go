@@ -0,0 +1,120 @@
+package llmcostops
+
+import (
+	"context"
+)
+
+// PageFunc fetches a single page of results for an Iterator. page is
+// 1-based, matching PaginationParams.Page. It returns the records for that
+// page; a short page (len(records) < pageSize) or an empty page signals the
+// end of the list.
+type PageFunc[T any] func(ctx context.Context, page, pageSize int) ([]T, error)
+
+// Iterator walks a paginated list endpoint one record at a time, fetching
+// additional pages from its PageFunc on demand. It is built on the same
+// page/page_size query parameters as UsageService.List, CostService.List,
+// and PricingService.List, so it has no server-side dependency beyond what
+// those endpoints already support.
+//
+// Iterator is not safe for concurrent use.
+type Iterator[T any] struct {
+	fetch    PageFunc[T]
+	pageSize int
+
+	page int
+	buf  []T
+	idx  int
+	cur  T
+	err  error
+	done bool
+}
+
+// NewIterator returns an Iterator that fetches pageSize records at a time
+// via fetch. pageSize <= 0 defaults to 100.
+func NewIterator[T any](pageSize int, fetch PageFunc[T]) *Iterator[T] {
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	return &Iterator[T]{fetch: fetch, pageSize: pageSize}
+}
+
+// Next advances the iterator to the next record, fetching another page if
+// the current one is exhausted. It returns false when iteration is done,
+// either because the list is exhausted or because an error occurred; check
+// Err to distinguish the two.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.err != nil || it.done {
+		return false
+	}
+
+	for it.idx >= len(it.buf) {
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		it.page++
+		page, err := it.fetch(ctx, it.page, it.pageSize)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.buf = page
+		it.idx = 0
+
+		if len(page) < it.pageSize {
+			// Short page: this is the last one, but it may still hold
+			// records to yield before we report done.
+			it.done = len(page) == 0
+			break
+		}
+	}
+
+	if it.idx >= len(it.buf) {
+		return false
+	}
+
+	it.cur = it.buf[it.idx]
+	it.idx++
+	if it.idx >= len(it.buf) && len(it.buf) < it.pageSize {
+		it.done = true
+	}
+	return true
+}
+
+// Value returns the record most recently made current by Next.
+func (it *Iterator[T]) Value() T {
+	return it.cur
+}
+
+// Err returns the first error encountered while paging, if any. Callers
+// should check Err after Next returns false to distinguish end-of-list from
+// a fetch failure.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Close releases any resources held by the iterator. It is always safe to
+// call and always returns nil; it exists so Iterator satisfies the same
+// Close-on-defer convention as the rest of the SDK (e.g. Client.Close).
+func (it *Iterator[T]) Close() error {
+	it.done = true
+	return nil
+}
+
+// All drains it into a slice, stopping at the first error. It is a
+// convenience for callers who want the whole list in memory, equivalent to
+// calling Next/Value in a loop.
+func All[T any](ctx context.Context, it *Iterator[T]) ([]T, error) {
+	defer it.Close()
+
+	var all []T
+	for it.Next(ctx) {
+		all = append(all, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
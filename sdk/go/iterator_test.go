@@ -0,0 +1,183 @@
+package llmcostops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// newPaginatedUsageTestClient spins up a fake /api/v1/usage endpoint
+// serving total UsageRecords, ignoring the caller's page size and always
+// paginating by pageSize, to exercise UsageService.ListIterator/ListAll.
+func newPaginatedUsageTestClient(t *testing.T, pageSize, total int) (*Client, func()) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 1 {
+			page = 1
+		}
+
+		start := (page - 1) * pageSize
+		var records []UsageRecord
+		for i := start; i < start+pageSize && i < total; i++ {
+			records = append(records, UsageRecord{ID: fmt.Sprintf("usage-%d", i)})
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(records)
+	}))
+
+	client, err := NewClient(WithAPIKey("test-key"), WithBaseURL(server.URL))
+	if err != nil {
+		server.Close()
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	return client, func() {
+		client.Close()
+		server.Close()
+	}
+}
+
+func TestIterator_PagesUntilShortPage(t *testing.T) {
+	pageSize := 3
+	total := 7 // two full pages + one short page
+
+	var fetchedPages []int
+	it := NewIterator(pageSize, func(ctx context.Context, page, pageSize int) ([]int, error) {
+		fetchedPages = append(fetchedPages, page)
+		start := (page - 1) * pageSize
+		if start >= total {
+			return nil, nil
+		}
+		end := start + pageSize
+		if end > total {
+			end = total
+		}
+		out := make([]int, 0, end-start)
+		for i := start; i < end; i++ {
+			out = append(out, i)
+		}
+		return out, nil
+	})
+
+	var got []int
+	for it.Next(context.Background()) {
+		got = append(got, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != total {
+		t.Fatalf("got %d records, want %d: %v", len(got), total, got)
+	}
+	for i, v := range got {
+		if v != i {
+			t.Errorf("got[%d] = %d, want %d", i, v, i)
+		}
+	}
+	if len(fetchedPages) != 3 {
+		t.Errorf("fetched %d pages, want 3 (2 full + 1 short): %v", len(fetchedPages), fetchedPages)
+	}
+}
+
+func TestIterator_StopsOnEmptyPage(t *testing.T) {
+	// A final page exactly equal to pageSize should still trigger one more
+	// (empty) fetch before Next reports done, since a full page can't be
+	// distinguished from "more to come" without trying the next page.
+	pageSize := 2
+	pages := [][]int{{1, 2}, {}}
+	call := 0
+
+	it := NewIterator(pageSize, func(ctx context.Context, page, pageSize int) ([]int, error) {
+		defer func() { call++ }()
+		if call >= len(pages) {
+			return nil, nil
+		}
+		return pages[call], nil
+	})
+
+	var got []int
+	for it.Next(context.Background()) {
+		got = append(got, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %v, want [1 2]", got)
+	}
+}
+
+func TestIterator_PropagatesFetchError(t *testing.T) {
+	wantErr := errors.New("boom")
+	it := NewIterator(10, func(ctx context.Context, page, pageSize int) ([]int, error) {
+		return nil, wantErr
+	})
+
+	if it.Next(context.Background()) {
+		t.Fatal("Next returned true, want false on fetch error")
+	}
+	if !errors.Is(it.Err(), wantErr) {
+		t.Errorf("Err() = %v, want %v", it.Err(), wantErr)
+	}
+}
+
+func TestAll_DrainsIterator(t *testing.T) {
+	pageSize := 5
+	total := 12
+
+	it := NewIterator(pageSize, func(ctx context.Context, page, pageSize int) ([]int, error) {
+		start := (page - 1) * pageSize
+		if start >= total {
+			return nil, nil
+		}
+		end := start + pageSize
+		if end > total {
+			end = total
+		}
+		out := make([]int, 0, end-start)
+		for i := start; i < end; i++ {
+			out = append(out, i)
+		}
+		return out, nil
+	})
+
+	got, err := All(context.Background(), it)
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+	if len(got) != total {
+		t.Fatalf("All() returned %d records, want %d", len(got), total)
+	}
+}
+
+func TestUsageService_ListIterator(t *testing.T) {
+	const pageSize = 2
+	const total = 5
+
+	client, cleanup := newPaginatedUsageTestClient(t, pageSize, total)
+	defer cleanup()
+
+	got, err := client.Usage.ListAll(context.Background(), &UsageListParams{
+		PaginationParams: PaginationParams{PageSize: pageSize},
+	})
+	if err != nil {
+		t.Fatalf("ListAll() error = %v", err)
+	}
+	if len(got) != total {
+		t.Fatalf("ListAll() returned %d records, want %d", len(got), total)
+	}
+	for i, rec := range got {
+		if want := fmt.Sprintf("usage-%d", i); rec.ID != want {
+			t.Errorf("got[%d].ID = %q, want %q", i, rec.ID, want)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package llmcostops
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// MetricsService runs PromQL-style queries over UsageRecord/CostRecord
+// data, modeled on the Prometheus HTTP API (/api/v1/query,
+// /api/v1/query_range, /api/v1/label/<name>/values) so existing Grafana
+// Prometheus datasources can point at it directly. The SDK does not
+// evaluate expressions itself: Query/QueryRange/LabelValues pass the
+// expression string through to the server, the same way
+// github.com/prometheus/client_golang's api/prometheus/v1 package does.
+//
+// Expressions select over series keyed by labels such as provider, model,
+// project_id, user_id, and tag, and support the usual PromQL building
+// blocks: selectors (cost{provider="openai",model=~"gpt-4.*"}),
+// aggregations (sum by (model), avg, topk, bottomk, quantile), rate/increase
+// over token counters, and arithmetic/vector matching between series (e.g.
+// sum by (project_id) (rate(total_tokens[5m])) * on(model) group_left
+// pricing_input_per_million).
+type MetricsService struct {
+	client *Client
+}
+
+// SampleValue is a single metric sample at a point in time.
+type SampleValue struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
+}
+
+// Sample is one label set and its value, the element type of an
+// InstantVector result.
+type Sample struct {
+	Metric    map[string]string `json:"metric"`
+	Timestamp time.Time         `json:"timestamp"`
+	Value     float64           `json:"value"`
+}
+
+// Series is one label set and its values over a queried range, the element
+// type of a RangeMatrix result.
+type Series struct {
+	Metric map[string]string `json:"metric"`
+	Values []SampleValue     `json:"values"`
+}
+
+// QueryResultType mirrors Prometheus's result_type discriminator.
+type QueryResultType string
+
+const (
+	QueryResultTypeVector QueryResultType = "vector"
+	QueryResultTypeMatrix QueryResultType = "matrix"
+	QueryResultTypeScalar QueryResultType = "scalar"
+)
+
+// InstantVector is the result of MetricsService.Query: one Sample per
+// matched label set, as of the query's evaluation time.
+type InstantVector struct {
+	ResultType QueryResultType `json:"result_type"`
+	Result     []Sample        `json:"result"`
+}
+
+// RangeMatrix is the result of MetricsService.QueryRange: one Series per
+// matched label set, with values at each step across [start, end].
+type RangeMatrix struct {
+	ResultType QueryResultType `json:"result_type"`
+	Result     []Series        `json:"result"`
+}
+
+// Query evaluates expr as an instant query at time at. A zero at queries
+// the current time, matching the Prometheus HTTP API's default.
+func (s *MetricsService) Query(ctx context.Context, expr string, at time.Time) (*InstantVector, error) {
+	if expr == "" {
+		return nil, fmt.Errorf("%w: expr is required", ErrBadRequest)
+	}
+
+	req, err := s.client.newRequest(http.MethodGet, "/api/v1/query", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Set("query", expr)
+	if !at.IsZero() {
+		q.Set("time", at.Format(time.RFC3339))
+	}
+	req.URL.RawQuery = q.Encode()
+
+	var result InstantVector
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// QueryRange evaluates expr across [start, end] at the given step, the
+// range-query equivalent of Query.
+func (s *MetricsService) QueryRange(ctx context.Context, expr string, start, end time.Time, step time.Duration) (*RangeMatrix, error) {
+	if expr == "" {
+		return nil, fmt.Errorf("%w: expr is required", ErrBadRequest)
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("%w: end must be after start", ErrBadRequest)
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf("%w: step must be positive", ErrBadRequest)
+	}
+
+	req, err := s.client.newRequest(http.MethodGet, "/api/v1/query_range", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Set("query", expr)
+	q.Set("start", start.Format(time.RFC3339))
+	q.Set("end", end.Format(time.RFC3339))
+	q.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+	req.URL.RawQuery = q.Encode()
+
+	var result RangeMatrix
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// LabelValues returns the distinct values observed for label across the
+// series MetricsService exposes, for autocomplete in tools like Grafana's
+// query editor.
+func (s *MetricsService) LabelValues(ctx context.Context, label string) ([]string, error) {
+	if label == "" {
+		return nil, fmt.Errorf("%w: label is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/label/%s/values", url.PathEscape(label))
+	req, err := s.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
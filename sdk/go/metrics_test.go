@@ -0,0 +1,227 @@
+package llmcostops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMetricsService_Query(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		at      time.Time
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "valid expression",
+			expr: `sum by (model) (rate(total_tokens[5m]))`,
+		},
+		{
+			name: "valid expression with explicit time",
+			expr: `cost{provider="openai"}`,
+			at:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "empty expression",
+			expr:    "",
+			wantErr: true,
+			errType: ErrBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/query" {
+					t.Errorf("Expected /api/v1/query, got %s", r.URL.Path)
+				}
+				if got := r.URL.Query().Get("query"); got != tt.expr {
+					t.Errorf("Expected query=%q, got %q", tt.expr, got)
+				}
+				if !tt.at.IsZero() {
+					if got := r.URL.Query().Get("time"); got != tt.at.Format(time.RFC3339) {
+						t.Errorf("Expected time=%q, got %q", tt.at.Format(time.RFC3339), got)
+					}
+				} else if got := r.URL.Query().Get("time"); got != "" {
+					t.Errorf("Expected no time param, got %q", got)
+				}
+
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(InstantVector{
+					ResultType: QueryResultTypeVector,
+					Result: []Sample{
+						{Metric: map[string]string{"model": "gpt-4"}, Value: 42},
+					},
+				})
+			}))
+			defer server.Close()
+
+			client := setupTestClient(t, server.URL)
+			defer client.Close()
+
+			result, err := client.Metrics.Query(context.Background(), tt.expr, tt.at)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected error, got nil")
+				}
+				if tt.errType != nil && !isError(err, tt.errType) {
+					t.Errorf("Expected error type %v, got %v", tt.errType, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(result.Result) != 1 || result.Result[0].Value != 42 {
+				t.Errorf("Unexpected result: %+v", result)
+			}
+		})
+	}
+}
+
+func TestMetricsService_QueryRange(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		expr    string
+		start   time.Time
+		end     time.Time
+		step    time.Duration
+		wantErr bool
+		errType error
+	}{
+		{
+			name:  "valid range",
+			expr:  `sum(cost)`,
+			start: start,
+			end:   end,
+			step:  time.Minute,
+		},
+		{
+			name:    "empty expression",
+			expr:    "",
+			start:   start,
+			end:     end,
+			step:    time.Minute,
+			wantErr: true,
+			errType: ErrBadRequest,
+		},
+		{
+			name:    "end before start",
+			expr:    `sum(cost)`,
+			start:   end,
+			end:     start,
+			step:    time.Minute,
+			wantErr: true,
+			errType: ErrBadRequest,
+		},
+		{
+			name:    "non-positive step",
+			expr:    `sum(cost)`,
+			start:   start,
+			end:     end,
+			step:    0,
+			wantErr: true,
+			errType: ErrBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/query_range" {
+					t.Errorf("Expected /api/v1/query_range, got %s", r.URL.Path)
+				}
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode(RangeMatrix{
+					ResultType: QueryResultTypeMatrix,
+					Result: []Series{
+						{
+							Metric: map[string]string{"model": "gpt-4"},
+							Values: []SampleValue{{Timestamp: tt.start, Value: 1}},
+						},
+					},
+				})
+			}))
+			defer server.Close()
+
+			client := setupTestClient(t, server.URL)
+			defer client.Close()
+
+			result, err := client.Metrics.QueryRange(context.Background(), tt.expr, tt.start, tt.end, tt.step)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected error, got nil")
+				}
+				if tt.errType != nil && !isError(err, tt.errType) {
+					t.Errorf("Expected error type %v, got %v", tt.errType, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(result.Result) != 1 {
+				t.Errorf("Unexpected result: %+v", result)
+			}
+		})
+	}
+}
+
+func TestMetricsService_LabelValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		label   string
+		wantErr bool
+		errType error
+	}{
+		{name: "valid label", label: "model"},
+		{name: "empty label", label: "", wantErr: true, errType: ErrBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/label/"+tt.label+"/values" {
+					t.Errorf("Expected /api/v1/label/%s/values, got %s", tt.label, r.URL.Path)
+				}
+				w.WriteHeader(http.StatusOK)
+				json.NewEncoder(w).Encode([]string{"gpt-4", "claude-3-opus"})
+			}))
+			defer server.Close()
+
+			client := setupTestClient(t, server.URL)
+			defer client.Close()
+
+			result, err := client.Metrics.LabelValues(context.Background(), tt.label)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected error, got nil")
+				}
+				if tt.errType != nil && !isError(err, tt.errType) {
+					t.Errorf("Expected error type %v, got %v", tt.errType, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(result) != 2 {
+				t.Errorf("Unexpected result: %+v", result)
+			}
+		})
+	}
+}
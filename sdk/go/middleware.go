@@ -0,0 +1,303 @@
+package llmcostops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Handler executes a single HTTP request attempt and returns the raw
+// response, before rate limiting, retries, and decoding are applied.
+type Handler func(ctx context.Context, req *http.Request) (*http.Response, error)
+
+// Middleware wraps a Handler to add cross-cutting behavior — tracing,
+// metrics, logging, idempotency keys, circuit breaking — around every
+// attempt Client.do makes. Middlewares compose like net/http handlers:
+// the Middleware closest to WithMiddleware's argument list runs
+// outermost.
+type Middleware func(Handler) Handler
+
+// WithMiddleware registers middlewares around Client.do's request
+// pipeline, applied between rate limiting and the raw HTTP round trip.
+// The first middleware passed is the outermost wrapper. Built-ins are
+// provided for tracing (TracingMiddleware), Prometheus metrics
+// (PrometheusMiddleware), request/response logging (LoggingMiddleware),
+// idempotency keys (IdempotencyKeyMiddleware), and circuit breaking
+// (CircuitBreakerMiddleware); callers can also supply their own without
+// forking do.
+func WithMiddleware(mws ...Middleware) Option {
+	return func(c *Config) error {
+		c.Middleware = append(c.Middleware, mws...)
+		return nil
+	}
+}
+
+// chainMiddleware composes mws around final, with mws[0] outermost.
+func chainMiddleware(final Handler, mws ...Middleware) Handler {
+	h := final
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+type attemptCtxKey struct{}
+
+func contextWithAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptCtxKey{}, attempt)
+}
+
+// AttemptFromContext returns the zero-based retry attempt number of the
+// request currently flowing through the middleware chain, or 0 outside
+// of one.
+func AttemptFromContext(ctx context.Context) int {
+	if v, ok := ctx.Value(attemptCtxKey{}).(int); ok {
+		return v
+	}
+	return 0
+}
+
+type idempotencyKeyCtxKey struct{}
+
+func contextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+// TracingMiddleware starts an OpenTelemetry span per request attempt,
+// tagged with http.method, http.status_code, and costops.retry_attempt.
+// Pass nil to use the global tracer provider.
+func TracingMiddleware(tracer trace.Tracer) Middleware {
+	if tracer == nil {
+		tracer = otel.Tracer("github.com/llm-devops/llm-cost-ops/sdk/go")
+	}
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			ctx, span := tracer.Start(ctx, "costops.request")
+			defer span.End()
+
+			span.SetAttributes(
+				attribute.String("http.method", req.Method),
+				attribute.String("http.url", req.URL.String()),
+				attribute.Int("costops.retry_attempt", AttemptFromContext(ctx)),
+			)
+
+			resp, err := next(ctx, req)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return resp, err
+			}
+			span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+			return resp, nil
+		}
+	}
+}
+
+// PrometheusMiddleware records a costops_sdk_request_duration_seconds
+// histogram, labeled by method and status code, against registerer. Pass
+// nil to register against prometheus.DefaultRegisterer.
+func PrometheusMiddleware(registerer prometheus.Registerer) Middleware {
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "costops_sdk_request_duration_seconds",
+		Help: "Duration of LLM Cost Ops SDK HTTP requests, by method and status code.",
+	}, []string{"method", "status_code"})
+	registerer.MustRegister(duration)
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+
+			status := "error"
+			if resp != nil {
+				status = fmt.Sprintf("%d", resp.StatusCode)
+			}
+			duration.WithLabelValues(req.Method, status).Observe(time.Since(start).Seconds())
+			return resp, err
+		}
+	}
+}
+
+// LoggingMiddleware logs each request attempt and its outcome at debug
+// level, redacting the Authorization header so credentials never reach
+// log output.
+func LoggingMiddleware(logger *zap.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			logger.Debug("sending request",
+				zap.String("method", req.Method),
+				zap.String("url", req.URL.String()),
+				zap.Any("headers", redactAuthorization(req.Header)),
+				zap.Int("attempt", AttemptFromContext(ctx)),
+			)
+
+			resp, err := next(ctx, req)
+			if err != nil {
+				logger.Debug("request failed", zap.Error(err))
+				return resp, err
+			}
+			logger.Debug("received response", zap.Int("status_code", resp.StatusCode))
+			return resp, nil
+		}
+	}
+}
+
+// redactAuthorization returns a copy of h with the Authorization header
+// value replaced, so it is safe to pass to a logger.
+func redactAuthorization(h http.Header) http.Header {
+	redacted := h.Clone()
+	if redacted.Get("Authorization") != "" {
+		redacted.Set("Authorization", "REDACTED")
+	}
+	return redacted
+}
+
+// IdempotencyKeyMiddleware sets an Idempotency-Key header on POST and PUT
+// requests that don't already carry one. The same key is reused across
+// Client.do's retry attempts for a given logical request, so a retried
+// mutation is safe for the server to de-duplicate.
+func IdempotencyKeyMiddleware() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			if req.Header.Get("Idempotency-Key") == "" && (req.Method == http.MethodPost || req.Method == http.MethodPut) {
+				key, ok := ctx.Value(idempotencyKeyCtxKey{}).(string)
+				if !ok || key == "" {
+					key = uuid.NewString()
+				}
+				req.Header.Set("Idempotency-Key", key)
+			}
+			return next(ctx, req)
+		}
+	}
+}
+
+// CircuitBreakerState is the state of a CircuitBreaker.
+type CircuitBreakerState int
+
+const (
+	// CircuitClosed lets requests through normally.
+	CircuitClosed CircuitBreakerState = iota
+	// CircuitOpen rejects requests immediately with ErrCircuitOpen.
+	CircuitOpen
+	// CircuitHalfOpen lets a single probe request through to decide
+	// whether to close again.
+	CircuitHalfOpen
+)
+
+// ErrCircuitOpen is returned instead of making a request while a
+// CircuitBreakerMiddleware's breaker is open, or half-open with its probe
+// request still in flight.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreakerOptions configures CircuitBreakerMiddleware.
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive 5xx responses or
+	// transport errors that trips the breaker open. Defaults to 5.
+	FailureThreshold int
+
+	// CooldownPeriod is how long the breaker stays open before letting a
+	// single half-open probe request through. Defaults to 30s.
+	CooldownPeriod time.Duration
+
+	// OnStateChange, if set, is called whenever the breaker transitions
+	// between states.
+	OnStateChange func(from, to CircuitBreakerState)
+}
+
+func (o CircuitBreakerOptions) withDefaults() CircuitBreakerOptions {
+	if o.FailureThreshold <= 0 {
+		o.FailureThreshold = 5
+	}
+	if o.CooldownPeriod <= 0 {
+		o.CooldownPeriod = 30 * time.Second
+	}
+	return o
+}
+
+// CircuitBreakerMiddleware implements a gobreaker-style circuit breaker:
+// it opens after FailureThreshold consecutive 5xx responses or transport
+// errors, rejects requests immediately while open, and half-opens after
+// CooldownPeriod to let a single probe request determine whether to
+// close again.
+func CircuitBreakerMiddleware(opts CircuitBreakerOptions) Middleware {
+	cb := &circuitBreaker{opts: opts.withDefaults()}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			if !cb.allow() {
+				return nil, ErrCircuitOpen
+			}
+
+			resp, err := next(ctx, req)
+			cb.record(err == nil && resp != nil && resp.StatusCode < 500)
+			return resp, err
+		}
+	}
+}
+
+type circuitBreaker struct {
+	opts CircuitBreakerOptions
+
+	mu               sync.Mutex
+	state            CircuitBreakerState
+	consecutiveFails int
+	openedAt         time.Time
+}
+
+func (cb *circuitBreaker) allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CircuitHalfOpen {
+		return false
+	}
+	if cb.state == CircuitOpen {
+		if time.Since(cb.openedAt) < cb.opts.CooldownPeriod {
+			return false
+		}
+		cb.transition(CircuitHalfOpen)
+	}
+	return true
+}
+
+func (cb *circuitBreaker) record(success bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if success {
+		cb.consecutiveFails = 0
+		if cb.state != CircuitClosed {
+			cb.transition(CircuitClosed)
+		}
+		return
+	}
+
+	cb.consecutiveFails++
+	if cb.state == CircuitHalfOpen || cb.consecutiveFails >= cb.opts.FailureThreshold {
+		cb.openedAt = time.Now()
+		cb.transition(CircuitOpen)
+	}
+}
+
+func (cb *circuitBreaker) transition(to CircuitBreakerState) {
+	from := cb.state
+	cb.state = to
+	if from != to && cb.opts.OnStateChange != nil {
+		cb.opts.OnStateChange(from, to)
+	}
+}
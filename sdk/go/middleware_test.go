@@ -0,0 +1,110 @@
+package llmcostops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Middleware_AppliedInOrder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(HealthStatus{Status: "ok"})
+	}))
+	defer server.Close()
+
+	var order []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next(ctx, req)
+			}
+		}
+	}
+
+	client, err := NewClient(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithMiddleware(record("outer"), record("inner")),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.Health.Check(context.Background()); err != nil {
+		t.Fatalf("Health.Check() error = %v", err)
+	}
+
+	if len(order) != 2 || order[0] != "outer" || order[1] != "inner" {
+		t.Errorf("middleware order = %v, want [outer inner]", order)
+	}
+}
+
+func TestIdempotencyKeyMiddleware(t *testing.T) {
+	var gotKeys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKeys = append(gotKeys, r.Header.Get("Idempotency-Key"))
+		if len(gotKeys) < 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithMaxRetries(2),
+		WithMiddleware(IdempotencyKeyMiddleware()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	req, err := client.newRequest(http.MethodPost, "/api/v1/usage", map[string]string{"x": "y"})
+	if err != nil {
+		t.Fatalf("newRequest() error = %v", err)
+	}
+	if err := client.do(context.Background(), req, nil); err != nil {
+		t.Fatalf("do() error = %v", err)
+	}
+
+	if len(gotKeys) != 2 || gotKeys[0] == "" || gotKeys[0] != gotKeys[1] {
+		t.Errorf("Idempotency-Key across retries = %v, want two identical non-empty values", gotKeys)
+	}
+}
+
+func TestCircuitBreakerMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(
+		WithAPIKey("test-key"),
+		WithBaseURL(server.URL),
+		WithMaxRetries(0),
+		WithMiddleware(CircuitBreakerMiddleware(CircuitBreakerOptions{FailureThreshold: 1})),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	if _, err := client.Health.Check(ctx); err == nil {
+		t.Fatal("expected first request to fail with a server error")
+	}
+
+	_, err = client.Health.Check(ctx)
+	if !isError(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen on second request, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package llmcostops
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -101,14 +102,14 @@ type PricingStructure struct {
 	Type string `json:"type"` // "per_token", "per_request", "tiered"
 
 	// For per_token pricing
-	InputPricePerMillion  *float64 `json:"input_price_per_million,omitempty"`
-	OutputPricePerMillion *float64 `json:"output_price_per_million,omitempty"`
-	CachedInputDiscount   *float64 `json:"cached_input_discount,omitempty"`
+	InputPricePerMillion  *Decimal `json:"input_price_per_million,omitempty"`
+	OutputPricePerMillion *Decimal `json:"output_price_per_million,omitempty"`
+	CachedInputDiscount   *Decimal `json:"cached_input_discount,omitempty"`
 
 	// For per_request pricing
-	PricePerRequest        *float64 `json:"price_per_request,omitempty"`
+	PricePerRequest        *Decimal `json:"price_per_request,omitempty"`
 	IncludedTokens         *int64   `json:"included_tokens,omitempty"`
-	OveragePricePerMillion *float64 `json:"overage_price_per_million,omitempty"`
+	OveragePricePerMillion *Decimal `json:"overage_price_per_million,omitempty"`
 
 	// For tiered pricing
 	Tiers []PricingTier `json:"tiers,omitempty"`
@@ -117,8 +118,52 @@ type PricingStructure struct {
 // PricingTier represents a pricing tier
 type PricingTier struct {
 	Threshold             int64   `json:"threshold"`
-	InputPricePerMillion  float64 `json:"input_price_per_million"`
-	OutputPricePerMillion float64 `json:"output_price_per_million"`
+	InputPricePerMillion  Decimal `json:"input_price_per_million"`
+	OutputPricePerMillion Decimal `json:"output_price_per_million"`
+}
+
+// Validate rejects a PricingStructure with negative, overflowing, or
+// otherwise nonsensical values — the bogus states a float64-backed
+// InputPricePerMillion could previously carry silently (e.g. NaN
+// propagating through a sum, or a negative price from an inverted
+// subtraction upstream).
+func (p *PricingStructure) Validate() error {
+	checks := []struct {
+		name string
+		d    *Decimal
+	}{
+		{"input_price_per_million", p.InputPricePerMillion},
+		{"output_price_per_million", p.OutputPricePerMillion},
+		{"cached_input_discount", p.CachedInputDiscount},
+		{"price_per_request", p.PricePerRequest},
+		{"overage_price_per_million", p.OveragePricePerMillion},
+	}
+	for _, c := range checks {
+		if c.d == nil {
+			continue
+		}
+		if c.d.IsNegative() {
+			return fmt.Errorf("%w: %s cannot be negative", ErrBadRequest, c.name)
+		}
+		if c.d.Exponent() < -maxPricingScale {
+			return fmt.Errorf("%w: %s has more than %d decimal places", ErrBadRequest, c.name, maxPricingScale)
+		}
+	}
+
+	if p.IncludedTokens != nil && *p.IncludedTokens < 0 {
+		return fmt.Errorf("%w: included_tokens cannot be negative", ErrBadRequest)
+	}
+
+	for i, tier := range p.Tiers {
+		if tier.Threshold < 0 {
+			return fmt.Errorf("%w: tiers[%d].threshold cannot be negative", ErrBadRequest, i)
+		}
+		if tier.InputPricePerMillion.IsNegative() || tier.OutputPricePerMillion.IsNegative() {
+			return fmt.Errorf("%w: tiers[%d] prices cannot be negative", ErrBadRequest, i)
+		}
+	}
+
+	return nil
 }
 
 // CostSummary represents aggregated cost data
@@ -155,6 +200,15 @@ const (
 	FormatCSV   ExportFormat = "csv"
 	FormatExcel ExportFormat = "xlsx"
 	FormatJSONL ExportFormat = "jsonl"
+
+	// FormatPrometheus renders cost/usage rows as Prometheus text
+	// exposition format, labeled by provider, model, project_id, and
+	// organization_id. FormatOpenMetrics is the same data in the
+	// OpenMetrics exposition format. See also ExportService.RemoteWrite,
+	// which pushes the same series to a remote_write endpoint instead of
+	// returning them for scraping.
+	FormatPrometheus  ExportFormat = "prometheus"
+	FormatOpenMetrics ExportFormat = "openmetrics"
 )
 
 // Filters represents query filters
@@ -165,8 +219,8 @@ type Filters struct {
 	ProjectID      string     `json:"project_id,omitempty"`
 	UserID         string     `json:"user_id,omitempty"`
 	Tags           []string   `json:"tags,omitempty"`
-	MinCost        *float64   `json:"min_cost,omitempty"`
-	MaxCost        *float64   `json:"max_cost,omitempty"`
+	MinCost        *Decimal   `json:"min_cost,omitempty"`
+	MaxCost        *Decimal   `json:"max_cost,omitempty"`
 }
 
 // HealthStatus represents the health status of the service
@@ -174,7 +228,14 @@ type HealthStatus struct {
 	Status    string            `json:"status"`
 	Version   string            `json:"version"`
 	Timestamp time.Time         `json:"timestamp"`
+	Uptime    time.Duration     `json:"uptime,omitempty"`
 	Checks    map[string]Health `json:"checks"`
+
+	// Components carries per-subsystem health (e.g. "database",
+	// "ingest_queue", "pricing_catalog", "provider_meter_openai"). Checks is
+	// kept for backward compatibility with callers built against the
+	// coarser health model.
+	Components map[string]ComponentHealth `json:"components,omitempty"`
 }
 
 // Health represents a health check result
@@ -183,6 +244,24 @@ type Health struct {
 	Message string `json:"message,omitempty"`
 }
 
+// ComponentHealthState is the status of an individual subsystem.
+type ComponentHealthState string
+
+const (
+	ComponentHealthy   ComponentHealthState = "healthy"
+	ComponentDegraded  ComponentHealthState = "degraded"
+	ComponentUnhealthy ComponentHealthState = "unhealthy"
+)
+
+// ComponentHealth is the health of a single named subsystem within
+// HealthStatus.Components.
+type ComponentHealth struct {
+	Status      ComponentHealthState `json:"status"`
+	Message     string               `json:"message,omitempty"`
+	LatencyMs   int64                `json:"latency_ms,omitempty"`
+	LastChecked time.Time            `json:"last_checked"`
+}
+
 // ListResponse represents a paginated list response
 type ListResponse struct {
 	Data       interface{} `json:"data"`
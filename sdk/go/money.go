@@ -0,0 +1,149 @@
+package llmcostops
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Money is a currency-tagged decimal amount. It is the typed counterpart to
+// the plain decimal-string cost fields (CostRecord.TotalCost,
+// CostSummary.TotalCost, and friends) kept on the wire for backward
+// compatibility; Money marshals to and from that same string form so
+// existing consumers of the JSON API are unaffected.
+type Money struct {
+	Amount   decimal.Decimal
+	Currency Currency
+}
+
+// NewMoney constructs a Money value from a decimal string amount.
+func NewMoney(amount string, currency Currency) (Money, error) {
+	d, err := decimal.NewFromString(amount)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: invalid amount %q: %v", ErrBadRequest, amount, err)
+	}
+	return Money{Amount: d, Currency: currency}, nil
+}
+
+// MarshalJSON renders Money as the plain decimal string the API already
+// expects, e.g. "12.3400", so it round-trips through existing fields.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + m.Amount.String() + `"`), nil
+}
+
+// UnmarshalJSON parses a decimal string amount into Money. The Currency
+// field is left unset; callers that need currency should set it themselves
+// after unmarshaling, since the string form doesn't carry one.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	if s == "" || s == "null" {
+		m.Amount = decimal.Zero
+		return nil
+	}
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return fmt.Errorf("failed to decode Money: %w", err)
+	}
+	m.Amount = d
+	return nil
+}
+
+// String returns the decimal amount as a string, ignoring currency.
+func (m Money) String() string {
+	return m.Amount.String()
+}
+
+// Add returns m + other. Panics if the currencies differ and neither is
+// empty, matching the strictness expected of financial arithmetic.
+func (m Money) Add(other Money) Money {
+	m.mustMatchCurrency(other)
+	return Money{Amount: m.Amount.Add(other.Amount), Currency: m.currencyOrOther(other)}
+}
+
+// Sub returns m - other.
+func (m Money) Sub(other Money) Money {
+	m.mustMatchCurrency(other)
+	return Money{Amount: m.Amount.Sub(other.Amount), Currency: m.currencyOrOther(other)}
+}
+
+// Mul returns m * factor.
+func (m Money) Mul(factor decimal.Decimal) Money {
+	return Money{Amount: m.Amount.Mul(factor), Currency: m.Currency}
+}
+
+// Div returns m / divisor.
+func (m Money) Div(divisor decimal.Decimal) Money {
+	return Money{Amount: m.Amount.Div(divisor), Currency: m.Currency}
+}
+
+// Percent returns m * (pct / 100), e.g. m.Percent(decimal.NewFromInt(80))
+// for 80% of m.
+func (m Money) Percent(pct decimal.Decimal) Money {
+	return m.Mul(pct.Div(decimal.NewFromInt(100)))
+}
+
+func (m Money) mustMatchCurrency(other Money) {
+	if m.Currency != "" && other.Currency != "" && m.Currency != other.Currency {
+		panic(fmt.Sprintf("llmcostops: currency mismatch: %s vs %s", m.Currency, other.Currency))
+	}
+}
+
+func (m Money) currencyOrOther(other Money) Currency {
+	if m.Currency != "" {
+		return m.Currency
+	}
+	return other.Currency
+}
+
+// TotalCostDecimal parses CostSummary.TotalCost into a Money value without
+// the precision loss of round-tripping through float64.
+func (s *CostSummary) TotalCostDecimal() (Money, error) {
+	return NewMoney(s.TotalCost, CurrencyUSD)
+}
+
+// AvgCostDecimal parses CostSummary.AvgCost into a Money value.
+func (s *CostSummary) AvgCostDecimal() (Money, error) {
+	return NewMoney(s.AvgCost, CurrencyUSD)
+}
+
+// ByProviderDecimal parses CostSummary.ByProvider into Money values, keyed
+// by provider. Entries that fail to parse are omitted.
+func (s *CostSummary) ByProviderDecimal() map[Provider]Money {
+	out := make(map[Provider]Money, len(s.ByProvider))
+	for k, v := range s.ByProvider {
+		if m, err := NewMoney(v, CurrencyUSD); err == nil {
+			out[k] = m
+		}
+	}
+	return out
+}
+
+// ByModelDecimal parses CostSummary.ByModel into Money values, keyed by
+// model name. Entries that fail to parse are omitted.
+func (s *CostSummary) ByModelDecimal() map[string]Money {
+	out := make(map[string]Money, len(s.ByModel))
+	for k, v := range s.ByModel {
+		if m, err := NewMoney(v, CurrencyUSD); err == nil {
+			out[k] = m
+		}
+	}
+	return out
+}
+
+// TotalCostDecimal parses CostRecord.TotalCost into a Money value.
+func (r *CostRecord) TotalCostDecimal() (Money, error) {
+	return NewMoney(r.TotalCost, r.Currency)
+}
+
+// InputCostDecimal parses CostRecord.InputCost into a Money value.
+func (r *CostRecord) InputCostDecimal() (Money, error) {
+	return NewMoney(r.InputCost, r.Currency)
+}
+
+// OutputCostDecimal parses CostRecord.OutputCost into a Money value.
+func (r *CostRecord) OutputCostDecimal() (Money, error) {
+	return NewMoney(r.OutputCost, r.Currency)
+}
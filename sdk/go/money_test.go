@@ -0,0 +1,61 @@
+package llmcostops
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestMoney_JSONRoundTrip(t *testing.T) {
+	m, err := NewMoney("12.3400", CurrencyUSD)
+	if err != nil {
+		t.Fatalf("NewMoney() error = %v", err)
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != `"12.34"` {
+		t.Errorf("Marshal() = %s, want %q", data, `"12.34"`)
+	}
+
+	var decoded Money
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !decoded.Amount.Equal(m.Amount) {
+		t.Errorf("decoded amount = %s, want %s", decoded.Amount, m.Amount)
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	a, _ := NewMoney("10.00", CurrencyUSD)
+	b, _ := NewMoney("2.50", CurrencyUSD)
+
+	if got := a.Add(b).Amount.String(); got != "12.5" {
+		t.Errorf("Add() = %s, want 12.5", got)
+	}
+	if got := a.Sub(b).Amount.String(); got != "7.5" {
+		t.Errorf("Sub() = %s, want 7.5", got)
+	}
+	if got := a.Mul(decimal.NewFromInt(3)).Amount.String(); got != "30" {
+		t.Errorf("Mul() = %s, want 30", got)
+	}
+	if got := a.Percent(decimal.NewFromInt(50)).Amount.String(); got != "5" {
+		t.Errorf("Percent() = %s, want 5", got)
+	}
+}
+
+func TestMoney_CurrencyMismatchPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic on currency mismatch")
+		}
+	}()
+
+	usd, _ := NewMoney("1", CurrencyUSD)
+	eur, _ := NewMoney("1", CurrencyEUR)
+	_ = usd.Add(eur)
+}
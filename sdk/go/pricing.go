@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 // PricingService handles pricing-related API operations
@@ -16,9 +17,9 @@ type PricingService struct {
 type PricingAddParams struct {
 	Provider              Provider `json:"provider"`
 	Model                 string   `json:"model"`
-	InputPricePerMillion  float64  `json:"input_price_per_million"`
-	OutputPricePerMillion float64  `json:"output_price_per_million"`
-	CachedInputDiscount   *float64 `json:"cached_input_discount,omitempty"`
+	InputPricePerMillion  Decimal  `json:"input_price_per_million"`
+	OutputPricePerMillion Decimal  `json:"output_price_per_million"`
+	CachedInputDiscount   *Decimal `json:"cached_input_discount,omitempty"`
 	Currency              Currency `json:"currency,omitempty"`
 	EffectiveDate         string   `json:"effective_date,omitempty"`
 }
@@ -31,7 +32,11 @@ type PricingListParams struct {
 	PaginationParams
 }
 
-// Add adds a new pricing table entry
+// Add adds a new pricing table entry. When params.EffectiveDate supersedes
+// the row currently active for (Provider, Model), the server atomically
+// closes that row's EndDate so the two windows are adjacent rather than
+// overlapping; it rejects the request if the new window would overlap any
+// other row for the same (Provider, Model).
 func (s *PricingService) Add(ctx context.Context, params *PricingAddParams) (*PricingTable, error) {
 	if params == nil {
 		return nil, fmt.Errorf("%w: params cannot be nil", ErrBadRequest)
@@ -45,6 +50,13 @@ func (s *PricingService) Add(ctx context.Context, params *PricingAddParams) (*Pr
 		return nil, fmt.Errorf("%w: model is required", ErrBadRequest)
 	}
 
+	if params.InputPricePerMillion.IsNegative() || params.OutputPricePerMillion.IsNegative() {
+		return nil, fmt.Errorf("%w: prices cannot be negative", ErrBadRequest)
+	}
+	if params.CachedInputDiscount != nil && params.CachedInputDiscount.IsNegative() {
+		return nil, fmt.Errorf("%w: cached_input_discount cannot be negative", ErrBadRequest)
+	}
+
 	req, err := s.client.newRequest(http.MethodPost, "/api/v1/pricing", params)
 	if err != nil {
 		return nil, err
@@ -55,6 +67,8 @@ func (s *PricingService) Add(ctx context.Context, params *PricingAddParams) (*Pr
 		return nil, err
 	}
 
+	s.client.Webhooks.emit(EventPricingAdded, result)
+
 	return &result, nil
 }
 
@@ -114,6 +128,31 @@ func (s *PricingService) List(ctx context.Context, params *PricingListParams) ([
 	return result, nil
 }
 
+// ListIterator returns an Iterator over pricing tables matching params,
+// fetching additional pages from List as the caller consumes them. params
+// may be nil; its Page/PageSize are overwritten as the iterator pages
+// through the list, so a zero PageSize just selects the default page size.
+func (s *PricingService) ListIterator(params *PricingListParams) *Iterator[PricingTable] {
+	p := PricingListParams{}
+	if params != nil {
+		p = *params
+	}
+	pageSize := p.PageSize
+
+	return NewIterator(pageSize, func(ctx context.Context, page, pageSize int) ([]PricingTable, error) {
+		pageParams := p
+		pageParams.Page = page
+		pageParams.PageSize = pageSize
+		return s.List(ctx, &pageParams)
+	})
+}
+
+// ListAll drains ListIterator into a single slice, fetching every page of
+// pricing tables matching params.
+func (s *PricingService) ListAll(ctx context.Context, params *PricingListParams) ([]PricingTable, error) {
+	return All(ctx, s.ListIterator(params))
+}
+
 // Delete removes a pricing table by ID
 func (s *PricingService) Delete(ctx context.Context, id string) error {
 	if id == "" {
@@ -126,7 +165,13 @@ func (s *PricingService) Delete(ctx context.Context, id string) error {
 		return err
 	}
 
-	return s.client.do(ctx, req, nil)
+	if err := s.client.do(ctx, req, nil); err != nil {
+		return err
+	}
+
+	s.client.Webhooks.emit(EventPricingDeleted, PricingTable{ID: id})
+
+	return nil
 }
 
 // GetActive retrieves the active pricing for a specific provider and model
@@ -151,3 +196,98 @@ func (s *PricingService) GetActive(ctx context.Context, provider Provider, model
 
 	return &result, nil
 }
+
+// GetAt retrieves the pricing table row that was effective for provider and
+// model at the given instant, i.e. the row whose [EffectiveDate, EndDate)
+// window contains at. Use this instead of GetActive when recomputing the
+// cost of a historical UsageRecord, since the currently active row may not
+// be the one that was active when the record's usage occurred.
+func (s *PricingService) GetAt(ctx context.Context, provider Provider, model string, at time.Time) (*PricingTable, error) {
+	if provider == "" {
+		return nil, fmt.Errorf("%w: provider is required", ErrBadRequest)
+	}
+	if model == "" {
+		return nil, fmt.Errorf("%w: model is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/pricing/at/%s/%s", url.PathEscape(string(provider)), url.PathEscape(model))
+	req, err := s.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Set("at", at.UTC().Format(time.RFC3339))
+	req.URL.RawQuery = q.Encode()
+
+	var result PricingTable
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListHistory retrieves every pricing table row ever recorded for provider
+// and model, ordered by EffectiveDate, including superseded and
+// not-yet-effective scheduled rows.
+func (s *PricingService) ListHistory(ctx context.Context, provider Provider, model string) ([]PricingTable, error) {
+	if provider == "" {
+		return nil, fmt.Errorf("%w: provider is required", ErrBadRequest)
+	}
+	if model == "" {
+		return nil, fmt.Errorf("%w: model is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/pricing/history/%s/%s", url.PathEscape(string(provider)), url.PathEscape(model))
+	req, err := s.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []PricingTable
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// PricingDiff is the result of PricingService.Diff: the pricing rows
+// effective at two points in time, and the per-field deltas between them.
+// A delta is nil if either side has no value for that field (e.g. one of
+// the rows isn't per_token pricing).
+type PricingDiff struct {
+	From *PricingTable
+	To   *PricingTable
+
+	InputPricePerMillionDelta  *Decimal
+	OutputPricePerMillionDelta *Decimal
+}
+
+// Diff retrieves the pricing effective for provider and model at from and
+// at to, and returns the delta between their per-token prices.
+func (s *PricingService) Diff(ctx context.Context, provider Provider, model string, from, to time.Time) (*PricingDiff, error) {
+	fromTable, err := s.GetAt(ctx, provider, model, from)
+	if err != nil {
+		return nil, fmt.Errorf("resolving pricing at from: %w", err)
+	}
+	toTable, err := s.GetAt(ctx, provider, model, to)
+	if err != nil {
+		return nil, fmt.Errorf("resolving pricing at to: %w", err)
+	}
+
+	diff := &PricingDiff{From: fromTable, To: toTable}
+	diff.InputPricePerMillionDelta = decimalDelta(fromTable.PricingStructure.InputPricePerMillion, toTable.PricingStructure.InputPricePerMillion)
+	diff.OutputPricePerMillionDelta = decimalDelta(fromTable.PricingStructure.OutputPricePerMillion, toTable.PricingStructure.OutputPricePerMillion)
+	return diff, nil
+}
+
+// decimalDelta returns to-from as a Decimal, or nil if either side is nil.
+func decimalDelta(from, to *Decimal) *Decimal {
+	if from == nil || to == nil {
+		return nil
+	}
+	d := to.Decimal.Sub(from.Decimal)
+	return &Decimal{Decimal: d}
+}
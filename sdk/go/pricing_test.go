@@ -21,8 +21,8 @@ func TestPricingService_Add(t *testing.T) {
 			params: &PricingAddParams{
 				Provider:              ProviderOpenAI,
 				Model:                 "gpt-4",
-				InputPricePerMillion:  10.0,
-				OutputPricePerMillion: 30.0,
+				InputPricePerMillion:  NewDecimalFromFloat(10.0),
+				OutputPricePerMillion: NewDecimalFromFloat(30.0),
 				Currency:              CurrencyUSD,
 			},
 			wantErr: false,
@@ -37,8 +37,8 @@ func TestPricingService_Add(t *testing.T) {
 			name: "missing provider",
 			params: &PricingAddParams{
 				Model:                 "gpt-4",
-				InputPricePerMillion:  10.0,
-				OutputPricePerMillion: 30.0,
+				InputPricePerMillion:  NewDecimalFromFloat(10.0),
+				OutputPricePerMillion: NewDecimalFromFloat(30.0),
 			},
 			wantErr: true,
 			errType: ErrBadRequest,
@@ -47,8 +47,8 @@ func TestPricingService_Add(t *testing.T) {
 			name: "missing model",
 			params: &PricingAddParams{
 				Provider:              ProviderOpenAI,
-				InputPricePerMillion:  10.0,
-				OutputPricePerMillion: 30.0,
+				InputPricePerMillion:  NewDecimalFromFloat(10.0),
+				OutputPricePerMillion: NewDecimalFromFloat(30.0),
 			},
 			wantErr: true,
 			errType: ErrBadRequest,
@@ -58,9 +58,9 @@ func TestPricingService_Add(t *testing.T) {
 			params: &PricingAddParams{
 				Provider:              ProviderAnthropic,
 				Model:                 "claude-3-opus",
-				InputPricePerMillion:  15.0,
-				OutputPricePerMillion: 75.0,
-				CachedInputDiscount:   floatPtr(0.5),
+				InputPricePerMillion:  NewDecimalFromFloat(15.0),
+				OutputPricePerMillion: NewDecimalFromFloat(75.0),
+				CachedInputDiscount:   decimalPtr(0.5),
 			},
 			wantErr: false,
 		},
@@ -301,8 +301,8 @@ func TestPricingService_GetActive(t *testing.T) {
 					Model:    tt.model,
 					PricingStructure: PricingStructure{
 						Type:                  "per_token",
-						InputPricePerMillion:  floatPtr(10.0),
-						OutputPricePerMillion: floatPtr(30.0),
+						InputPricePerMillion:  decimalPtr(10.0),
+						OutputPricePerMillion: decimalPtr(30.0),
 					},
 					EffectiveDate: time.Now(),
 				})
@@ -334,6 +334,93 @@ func TestPricingService_GetActive(t *testing.T) {
 	}
 }
 
+func TestPricingService_GetAt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/pricing/at/openai/gpt-4" {
+			t.Errorf("Expected /api/v1/pricing/at/openai/gpt-4, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("at") == "" {
+			t.Error("Expected at query parameter")
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(PricingTable{ID: "price-123", Provider: ProviderOpenAI, Model: "gpt-4"})
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	result, err := client.Pricing.GetAt(context.Background(), ProviderOpenAI, "gpt-4", time.Now())
+	if err != nil {
+		t.Fatalf("GetAt() error = %v", err)
+	}
+	if result.ID != "price-123" {
+		t.Errorf("GetAt() = %s, want price-123", result.ID)
+	}
+
+	if _, err := client.Pricing.GetAt(context.Background(), "", "gpt-4", time.Now()); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestPricingService_ListHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/pricing/history/openai/gpt-4" {
+			t.Errorf("Expected /api/v1/pricing/history/openai/gpt-4, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode([]PricingTable{{ID: "price-1"}, {ID: "price-2"}})
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	result, err := client.Pricing.ListHistory(context.Background(), ProviderOpenAI, "gpt-4")
+	if err != nil {
+		t.Fatalf("ListHistory() error = %v", err)
+	}
+	if len(result) != 2 {
+		t.Errorf("ListHistory() returned %d rows, want 2", len(result))
+	}
+}
+
+func TestPricingService_Diff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		at := r.URL.Query().Get("at")
+		table := PricingTable{
+			ID: "price-before",
+			PricingStructure: PricingStructure{
+				Type:                  "per_token",
+				InputPricePerMillion:  decimalPtr(10.0),
+				OutputPricePerMillion: decimalPtr(30.0),
+			},
+		}
+		if at == time.Unix(2000000000, 0).UTC().Format(time.RFC3339) {
+			table.ID = "price-after"
+			table.PricingStructure.InputPricePerMillion = decimalPtr(8.0)
+			table.PricingStructure.OutputPricePerMillion = decimalPtr(24.0)
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(table)
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	diff, err := client.Pricing.Diff(context.Background(), ProviderOpenAI, "gpt-4", time.Unix(1000000000, 0), time.Unix(2000000000, 0))
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	if diff.From.ID != "price-before" || diff.To.ID != "price-after" {
+		t.Errorf("Diff() From/To = %s/%s, want price-before/price-after", diff.From.ID, diff.To.ID)
+	}
+	if !diff.InputPricePerMillionDelta.Equal(MustDecimal("-2").Decimal) {
+		t.Errorf("InputPricePerMillionDelta = %s, want -2", diff.InputPricePerMillionDelta)
+	}
+}
+
 // Helper functions
 func setupTestClient(t *testing.T, baseURL string) *Client {
 	t.Helper()
@@ -352,6 +439,11 @@ func floatPtr(f float64) *float64 {
 	return &f
 }
 
+func decimalPtr(f float64) *Decimal {
+	d := NewDecimalFromFloat(f)
+	return &d
+}
+
 func boolPtr(b bool) *bool {
 	return &b
 }
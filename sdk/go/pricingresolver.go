@@ -0,0 +1,138 @@
+package llmcostops
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrOverlappingPricingWindow is returned when PricingResolver loads two
+// pricing table rows for the same (provider, model) whose
+// [EffectiveDate, EndDate) windows overlap. The server rejects writes that
+// would create such a pair; seeing it client-side means the cached history
+// is corrupt or stale.
+var ErrOverlappingPricingWindow = fmt.Errorf("%w: overlapping pricing windows", ErrBadRequest)
+
+type pricingHistoryKey struct {
+	provider Provider
+	model    string
+}
+
+// PricingResolver caches each (provider, model)'s pricing history so
+// repeated point-in-time lookups — as done when recomputing cost for a
+// batch of historical UsageRecords — don't re-fetch the full history per
+// record. It is safe for concurrent use.
+type PricingResolver struct {
+	pricing *PricingService
+
+	mu      sync.RWMutex
+	history map[pricingHistoryKey][]PricingTable
+}
+
+// NewPricingResolver returns a PricingResolver backed by pricing.
+func NewPricingResolver(pricing *PricingService) *PricingResolver {
+	return &PricingResolver{
+		pricing: pricing,
+		history: make(map[pricingHistoryKey][]PricingTable),
+	}
+}
+
+// Resolve returns the pricing table row effective for provider and model
+// at the given instant, loading and caching that pair's full history on
+// first use. Use this instead of PricingService.GetAt when resolving many
+// timestamps for the same (provider, model), e.g. cost recomputation over
+// a UsageRecord history, where Timestamp must drive pricing selection
+// rather than whatever row is currently active.
+func (r *PricingResolver) Resolve(ctx context.Context, provider Provider, model string, at time.Time) (*PricingTable, error) {
+	rows, err := r.rows(ctx, provider, model)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		if at.Before(row.EffectiveDate) {
+			continue
+		}
+		if row.EndDate != nil && !at.Before(*row.EndDate) {
+			continue
+		}
+		return row, nil
+	}
+
+	return nil, fmt.Errorf("%w: no pricing effective for %s/%s at %s", ErrNotFound, provider, model, at.Format(time.RFC3339))
+}
+
+// CalculateForRecord resolves the pricing effective at record.Timestamp and
+// calculates its cost with calc, so historical recomputation always uses
+// the pricing that was active when the usage occurred rather than whatever
+// is active now.
+func (r *PricingResolver) CalculateForRecord(ctx context.Context, calc *CostCalculator, record *UsageRecord, currency Currency) (*CostBreakdown, error) {
+	if record == nil {
+		return nil, fmt.Errorf("%w: record is required", ErrBadRequest)
+	}
+
+	table, err := r.Resolve(ctx, record.Provider, record.Model.Name, record.Timestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	cached := int64(0)
+	if record.CachedTokens != nil {
+		cached = *record.CachedTokens
+	}
+	return calc.Calculate(&table.PricingStructure, currency, record.PromptTokens, record.CompletionTokens, cached)
+}
+
+// Invalidate discards the cached history for provider and model, so the
+// next Resolve call re-fetches it. Call this after adding pricing for that
+// pair through a PricingService not sharing this resolver's cache.
+func (r *PricingResolver) Invalidate(provider Provider, model string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.history, pricingHistoryKey{provider: provider, model: model})
+}
+
+// rows returns the cached, sorted, overlap-checked history for provider and
+// model, loading it from PricingService.ListHistory on a cache miss.
+func (r *PricingResolver) rows(ctx context.Context, provider Provider, model string) ([]PricingTable, error) {
+	key := pricingHistoryKey{provider: provider, model: model}
+
+	r.mu.RLock()
+	rows, ok := r.history[key]
+	r.mu.RUnlock()
+	if ok {
+		return rows, nil
+	}
+
+	rows, err := r.pricing.ListHistory(ctx, provider, model)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(rows, func(i, j int) bool { return rows[i].EffectiveDate.Before(rows[j].EffectiveDate) })
+	if err := validateNoOverlap(rows); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.history[key] = rows
+	r.mu.Unlock()
+
+	return rows, nil
+}
+
+// validateNoOverlap returns ErrOverlappingPricingWindow if any two
+// consecutive rows in rows (sorted by EffectiveDate) have overlapping
+// [EffectiveDate, EndDate) windows.
+func validateNoOverlap(rows []PricingTable) error {
+	for i := 1; i < len(rows); i++ {
+		prev := rows[i-1]
+		if prev.EndDate == nil || prev.EndDate.After(rows[i].EffectiveDate) {
+			return fmt.Errorf("%w: %s and %s", ErrOverlappingPricingWindow, prev.ID, rows[i].ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package llmcostops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newPricingHistoryTestClient(t *testing.T, rows []PricingTable) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(rows)
+	}))
+	t.Cleanup(server.Close)
+	return setupTestClient(t, server.URL)
+}
+
+func pricingRow(id string, effective time.Time, end *time.Time, inputPrice float64) PricingTable {
+	return PricingTable{
+		ID:            id,
+		Provider:      ProviderOpenAI,
+		Model:         "gpt-4",
+		EffectiveDate: effective,
+		EndDate:       end,
+		PricingStructure: PricingStructure{
+			Type:                  "per_token",
+			InputPricePerMillion:  decimalPtr(inputPrice),
+			OutputPricePerMillion: decimalPtr(inputPrice * 3),
+		},
+	}
+}
+
+func TestPricingResolver_Resolve(t *testing.T) {
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	rows := []PricingTable{
+		pricingRow("price-old", jan, &feb, 10.0),
+		pricingRow("price-new", feb, nil, 8.0),
+	}
+
+	client := newPricingHistoryTestClient(t, rows)
+	defer client.Close()
+	resolver := NewPricingResolver(client.Pricing)
+
+	got, err := resolver.Resolve(context.Background(), ProviderOpenAI, "gpt-4", jan.Add(15*24*time.Hour))
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got.ID != "price-old" {
+		t.Errorf("Resolve() = %s, want price-old", got.ID)
+	}
+
+	got, err = resolver.Resolve(context.Background(), ProviderOpenAI, "gpt-4", feb.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if got.ID != "price-new" {
+		t.Errorf("Resolve() = %s, want price-new", got.ID)
+	}
+}
+
+func TestPricingResolver_Resolve_BeforeAnyWindow(t *testing.T) {
+	feb := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	rows := []PricingTable{pricingRow("price-new", feb, nil, 8.0)}
+
+	client := newPricingHistoryTestClient(t, rows)
+	defer client.Close()
+	resolver := NewPricingResolver(client.Pricing)
+
+	_, err := resolver.Resolve(context.Background(), ProviderOpenAI, "gpt-4", feb.Add(-24*time.Hour))
+	if !isError(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestPricingResolver_Resolve_RejectsOverlappingWindows(t *testing.T) {
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	rows := []PricingTable{
+		pricingRow("price-a", jan, &mar, 10.0),
+		pricingRow("price-b", feb, nil, 8.0),
+	}
+
+	client := newPricingHistoryTestClient(t, rows)
+	defer client.Close()
+	resolver := NewPricingResolver(client.Pricing)
+
+	_, err := resolver.Resolve(context.Background(), ProviderOpenAI, "gpt-4", feb)
+	if !isError(err, ErrOverlappingPricingWindow) {
+		t.Errorf("expected ErrOverlappingPricingWindow, got %v", err)
+	}
+}
+
+func TestPricingResolver_CalculateForRecord(t *testing.T) {
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := []PricingTable{pricingRow("price-old", jan, nil, 10.0)}
+
+	client := newPricingHistoryTestClient(t, rows)
+	defer client.Close()
+	resolver := NewPricingResolver(client.Pricing)
+	calc := NewCostCalculator(CostCalculatorOptions{})
+
+	record := &UsageRecord{
+		Provider:         ProviderOpenAI,
+		Model:            Model{Name: "gpt-4"},
+		Timestamp:        jan.Add(time.Hour),
+		PromptTokens:     1_000_000,
+		CompletionTokens: 0,
+	}
+
+	got, err := resolver.CalculateForRecord(context.Background(), calc, record, CurrencyUSD)
+	if err != nil {
+		t.Fatalf("CalculateForRecord() error = %v", err)
+	}
+	assertMoneyEquals(t, "InputCost", got.InputCost, "10")
+}
+
+func TestPricingResolver_Invalidate(t *testing.T) {
+	jan := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := []PricingTable{pricingRow("price-old", jan, nil, 10.0)}
+
+	client := newPricingHistoryTestClient(t, rows)
+	defer client.Close()
+	resolver := NewPricingResolver(client.Pricing)
+
+	if _, err := resolver.Resolve(context.Background(), ProviderOpenAI, "gpt-4", jan); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	resolver.Invalidate(ProviderOpenAI, "gpt-4")
+
+	resolver.mu.RLock()
+	_, cached := resolver.history[pricingHistoryKey{provider: ProviderOpenAI, model: "gpt-4"}]
+	resolver.mu.RUnlock()
+	if cached {
+		t.Error("Invalidate() left a cached entry")
+	}
+}
@@ -0,0 +1,57 @@
+package providers
+
+import (
+	"context"
+	"time"
+
+	"github.com/anthropics/anthropic-sdk-go"
+
+	llmcostops "github.com/llm-devops/llm-cost-ops/sdk/go"
+)
+
+// AnthropicClient is the wrapped client returned by WrapAnthropic.
+type AnthropicClient struct {
+	raw *anthropic.Client
+	ing *ingester
+}
+
+// WrapAnthropic instruments an existing *anthropic.Client so every message
+// call automatically ingests a UsageRecord into costOpsClient.Usage.
+func WrapAnthropic(raw *anthropic.Client, costOpsClient *llmcostops.Client, opts Options) *AnthropicClient {
+	return &AnthropicClient{raw: raw, ing: newIngester(costOpsClient, opts)}
+}
+
+// Raw returns the underlying, uninstrumented Anthropic client.
+func (c *AnthropicClient) Raw() *anthropic.Client {
+	return c.raw
+}
+
+// CreateMessage proxies anthropic.Client.Messages.New and records
+// prompt/completion tokens, model name, and latency on return.
+func (c *AnthropicClient) CreateMessage(ctx context.Context, params anthropic.MessageNewParams) (*anthropic.Message, error) {
+	start := time.Now()
+	resp, err := c.raw.Messages.New(ctx, params)
+	if err != nil {
+		return resp, err
+	}
+
+	latency := time.Since(start).Milliseconds()
+	cached := int64(resp.Usage.CacheReadInputTokens)
+	c.ing.Record(ctx, llmcostops.UsageRecord{
+		Provider:         llmcostops.ProviderAnthropic,
+		Model:            llmcostops.Model{Name: string(resp.Model)},
+		PromptTokens:     int64(resp.Usage.InputTokens),
+		CompletionTokens: int64(resp.Usage.OutputTokens),
+		TotalTokens:      int64(resp.Usage.InputTokens + resp.Usage.OutputTokens),
+		CachedTokens:     &cached,
+		LatencyMs:        &latency,
+		Source:           &llmcostops.Source{Type: "sdk-wrapper", Endpoint: "messages"},
+	})
+
+	return resp, nil
+}
+
+// Close flushes any buffered usage records.
+func (c *AnthropicClient) Close() error {
+	return c.ing.Close()
+}
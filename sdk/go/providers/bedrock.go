@@ -0,0 +1,70 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+
+	llmcostops "github.com/llm-devops/llm-cost-ops/sdk/go"
+)
+
+// BedrockClient is the wrapped client returned by WrapBedrock.
+type BedrockClient struct {
+	raw *bedrockruntime.Client
+	ing *ingester
+}
+
+// WrapBedrock instruments an existing *bedrockruntime.Client so every
+// InvokeModel call automatically ingests a UsageRecord into
+// costOpsClient.Usage. Token counts are read from the response body's
+// "usage" field, which is present for Anthropic and Titan models served
+// through Bedrock; for providers that don't report usage, PromptTokens and
+// CompletionTokens are left at zero.
+func WrapBedrock(raw *bedrockruntime.Client, costOpsClient *llmcostops.Client, opts Options) *BedrockClient {
+	return &BedrockClient{raw: raw, ing: newIngester(costOpsClient, opts)}
+}
+
+// Raw returns the underlying, uninstrumented Bedrock runtime client.
+func (c *BedrockClient) Raw() *bedrockruntime.Client {
+	return c.raw
+}
+
+type bedrockUsage struct {
+	Usage struct {
+		InputTokens  int64 `json:"input_tokens"`
+		OutputTokens int64 `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// InvokeModel proxies bedrockruntime.Client.InvokeModel and records usage
+// parsed out of the model response body.
+func (c *BedrockClient) InvokeModel(ctx context.Context, params *bedrockruntime.InvokeModelInput) (*bedrockruntime.InvokeModelOutput, error) {
+	start := time.Now()
+	resp, err := c.raw.InvokeModel(ctx, params)
+	if err != nil {
+		return resp, err
+	}
+
+	var parsed bedrockUsage
+	_ = json.Unmarshal(resp.Body, &parsed)
+
+	latency := time.Since(start).Milliseconds()
+	c.ing.Record(ctx, llmcostops.UsageRecord{
+		Provider:         llmcostops.ProviderAWS,
+		Model:            llmcostops.Model{Name: *params.ModelId},
+		PromptTokens:     parsed.Usage.InputTokens,
+		CompletionTokens: parsed.Usage.OutputTokens,
+		TotalTokens:      parsed.Usage.InputTokens + parsed.Usage.OutputTokens,
+		LatencyMs:        &latency,
+		Source:           &llmcostops.Source{Type: "sdk-wrapper", Endpoint: "bedrock.invoke_model"},
+	})
+
+	return resp, nil
+}
+
+// Close flushes any buffered usage records.
+func (c *BedrockClient) Close() error {
+	return c.ing.Close()
+}
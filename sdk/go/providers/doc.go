@@ -0,0 +1,24 @@
+/*
+Package providers wraps official LLM client libraries (OpenAI, Anthropic,
+AWS Bedrock) so that usage is captured automatically instead of requiring
+every call site to hand-build a llmcostops.UsageRecord.
+
+	client, _ := llmcostops.NewClient(llmcostops.WithAPIKey("..."))
+	oai := openai.NewClient("sk-...")
+
+	wrapped := providers.WrapOpenAI(oai, client, providers.Options{
+		OrganizationID: "org-123",
+		ProjectID:      "proj-456",
+	})
+
+	resp, err := wrapped.CreateChatCompletion(ctx, openai.ChatCompletionRequest{...})
+
+Records are buffered in-process and flushed asynchronously on a size or time
+trigger; if the cost-ops server is unreachable the buffer degrades by
+dropping the oldest record rather than blocking the caller, with an optional
+Options.OnDrop hook for alerting on loss.
+
+Custom or unsupported providers can implement the Recorder interface to
+plug into the same buffering and flush behavior.
+*/
+package providers
@@ -0,0 +1,57 @@
+package providers
+
+import (
+	"context"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+
+	llmcostops "github.com/llm-devops/llm-cost-ops/sdk/go"
+)
+
+// OpenAIClient is the wrapped client returned by WrapOpenAI. It exposes the
+// subset of *openai.Client methods that the SDK instruments; call sites can
+// otherwise keep using the underlying *openai.Client directly via Raw().
+type OpenAIClient struct {
+	raw *openai.Client
+	ing *ingester
+}
+
+// WrapOpenAI instruments an existing *openai.Client so every chat completion
+// call automatically ingests a UsageRecord into costOpsClient.Usage.
+func WrapOpenAI(raw *openai.Client, costOpsClient *llmcostops.Client, opts Options) *OpenAIClient {
+	return &OpenAIClient{raw: raw, ing: newIngester(costOpsClient, opts)}
+}
+
+// Raw returns the underlying, uninstrumented OpenAI client.
+func (c *OpenAIClient) Raw() *openai.Client {
+	return c.raw
+}
+
+// CreateChatCompletion proxies openai.Client.CreateChatCompletion and
+// records prompt/completion tokens, model name, and latency on return.
+func (c *OpenAIClient) CreateChatCompletion(ctx context.Context, req openai.ChatCompletionRequest) (openai.ChatCompletionResponse, error) {
+	start := time.Now()
+	resp, err := c.raw.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+
+	latency := time.Since(start).Milliseconds()
+	c.ing.Record(ctx, llmcostops.UsageRecord{
+		Provider:         llmcostops.ProviderOpenAI,
+		Model:            llmcostops.Model{Name: resp.Model},
+		PromptTokens:     int64(resp.Usage.PromptTokens),
+		CompletionTokens: int64(resp.Usage.CompletionTokens),
+		TotalTokens:      int64(resp.Usage.TotalTokens),
+		LatencyMs:        &latency,
+		Source:           &llmcostops.Source{Type: "sdk-wrapper", Endpoint: "chat.completions"},
+	})
+
+	return resp, nil
+}
+
+// Close flushes any buffered usage records.
+func (c *OpenAIClient) Close() error {
+	return c.ing.Close()
+}
@@ -0,0 +1,155 @@
+// Package providers wraps official LLM client libraries so that every chat
+// or completion call automatically ingests a llmcostops.UsageRecord without
+// the caller having to thread token counts through their own code.
+package providers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	llmcostops "github.com/llm-devops/llm-cost-ops/sdk/go"
+)
+
+// Options configures how a wrapped provider client attributes and batches
+// the usage records it extracts from responses.
+type Options struct {
+	OrganizationID string
+	ProjectID      string
+	UserID         string
+	Tags           []string
+
+	// FlushInterval controls how often buffered records are ingested even if
+	// BatchSize has not been reached. Defaults to 5 seconds.
+	FlushInterval time.Duration
+
+	// BatchSize controls how many records are buffered before a flush is
+	// triggered. Defaults to 20.
+	BatchSize int
+
+	// OnDrop is invoked when the buffer is full and a record is dropped
+	// instead of blocking the caller. Useful for wiring up a metric.
+	OnDrop func(rec llmcostops.UsageRecord)
+}
+
+func (o Options) withDefaults() Options {
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = 5 * time.Second
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 20
+	}
+	return o
+}
+
+// Recorder is implemented by anything that can turn a provider response into
+// a UsageRecord. Users instrumenting a custom or unsupported provider can
+// implement Recorder and drive it through a Recorder-based wrapper built on
+// top of newIngester.
+type Recorder interface {
+	// Record extracts usage information from a provider call and enqueues it
+	// for ingestion. Implementations must not block on the network.
+	Record(ctx context.Context, rec llmcostops.UsageRecord)
+}
+
+// ingester buffers UsageRecords and flushes them to the cost-ops client on a
+// size or time trigger. It degrades gracefully under backpressure: once the
+// buffer is full, new records are dropped (oldest-first) rather than
+// blocking the instrumented call.
+type ingester struct {
+	client  *llmcostops.Client
+	opts    Options
+	mu      sync.Mutex
+	buf     []llmcostops.UsageRecord
+	flushCh chan struct{}
+	closed  chan struct{}
+	once    sync.Once
+}
+
+func newIngester(client *llmcostops.Client, opts Options) *ingester {
+	opts = opts.withDefaults()
+	ing := &ingester{
+		client:  client,
+		opts:    opts,
+		buf:     make([]llmcostops.UsageRecord, 0, opts.BatchSize),
+		flushCh: make(chan struct{}, 1),
+		closed:  make(chan struct{}),
+	}
+	go ing.loop()
+	return ing
+}
+
+func (ing *ingester) loop() {
+	ticker := time.NewTicker(ing.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ing.flush()
+		case <-ing.flushCh:
+			ing.flush()
+		case <-ing.closed:
+			ing.flush()
+			return
+		}
+	}
+}
+
+// Record enqueues a usage record for async ingestion. It never blocks.
+func (ing *ingester) Record(ctx context.Context, rec llmcostops.UsageRecord) {
+	if rec.OrganizationID == "" {
+		rec.OrganizationID = ing.opts.OrganizationID
+	}
+	if rec.ProjectID == "" {
+		rec.ProjectID = ing.opts.ProjectID
+	}
+	if rec.UserID == "" {
+		rec.UserID = ing.opts.UserID
+	}
+	if len(rec.Tags) == 0 {
+		rec.Tags = ing.opts.Tags
+	}
+
+	ing.mu.Lock()
+	if len(ing.buf) >= ing.opts.BatchSize*4 {
+		dropped := ing.buf[0]
+		ing.buf = ing.buf[1:]
+		ing.mu.Unlock()
+		if ing.opts.OnDrop != nil {
+			ing.opts.OnDrop(dropped)
+		}
+		ing.mu.Lock()
+	}
+	ing.buf = append(ing.buf, rec)
+	full := len(ing.buf) >= ing.opts.BatchSize
+	ing.mu.Unlock()
+
+	if full {
+		select {
+		case ing.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (ing *ingester) flush() {
+	ing.mu.Lock()
+	if len(ing.buf) == 0 {
+		ing.mu.Unlock()
+		return
+	}
+	batch := ing.buf
+	ing.buf = make([]llmcostops.UsageRecord, 0, ing.opts.BatchSize)
+	ing.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_ = ing.client.Usage.Ingest(ctx, &llmcostops.UsageIngestParams{Records: batch})
+}
+
+// Close flushes any buffered records and stops the background loop.
+func (ing *ingester) Close() error {
+	ing.once.Do(func() { close(ing.closed) })
+	return nil
+}
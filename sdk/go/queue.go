@@ -0,0 +1,246 @@
+package llmcostops
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// QueuedBatch is a batch of usage records pulled off a Queue for delivery.
+type QueuedBatch struct {
+	ID       string
+	Records  []UsageRecord
+	Attempts int
+	QueuedAt time.Time
+}
+
+// Queue is a durable outbox for usage records that couldn't be ingested
+// immediately (e.g. the server was unreachable). Implementations must be
+// safe for concurrent use.
+type Queue interface {
+	// Enqueue persists records for later delivery.
+	Enqueue(records []UsageRecord) error
+
+	// Dequeue returns up to n of the oldest queued batches. A returned
+	// batch is not checked out: it stays queued, and later Dequeue calls
+	// return it again, until it is Acked.
+	Dequeue(n int) ([]QueuedBatch, error)
+
+	// Ack permanently removes a batch after successful delivery.
+	Ack(batchID string) error
+
+	// Nack records a failed delivery attempt for a batch, incrementing its
+	// attempt count; the batch stays queued. reason describes the failure;
+	// MemoryQueue, BoltQueue and SQLiteQueue do not store it.
+	Nack(batchID string, reason string) error
+
+	// Depth returns the number of batches currently queued.
+	Depth() (int, error)
+
+	// OldestAge returns the age of the oldest queued batch, or zero if the
+	// queue is empty.
+	OldestAge() (time.Duration, error)
+}
+
+// WithPersistentQueue starts a background worker that drains q through
+// client.Usage.Ingest, using the client's existing rate limiter and retry
+// policy. Usage.Ingest does not write to q itself: records reach the queue
+// only through q.Enqueue, and a failed Ingest call returns its error
+// without queueing anything. Record IDs are not used as idempotency keys;
+// with IdempotencyKeyMiddleware installed, each Ingest call sends a fresh
+// random key.
+func WithPersistentQueue(q Queue) Option {
+	return func(c *Config) error {
+		c.persistentQueue = q
+		return nil
+	}
+}
+
+// queueWorker drains a Queue in the background, retrying failed batches
+// with the client's normal backoff via Usage.Ingest.
+type queueWorker struct {
+	client *Client
+	queue  Queue
+
+	pollInterval time.Duration
+	batchSize    int
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func startQueueWorker(client *Client, q Queue) *queueWorker {
+	w := &queueWorker{
+		client:       client,
+		queue:        q,
+		pollInterval: 2 * time.Second,
+		batchSize:    10,
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+func (w *queueWorker) run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.drain()
+		}
+	}
+}
+
+func (w *queueWorker) drain() {
+	batches, err := w.queue.Dequeue(w.batchSize)
+	if err != nil || len(batches) == 0 {
+		return
+	}
+
+	for _, batch := range batches {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err := w.client.Usage.Ingest(ctx, &UsageIngestParams{Records: batch.Records})
+		cancel()
+
+		if err != nil {
+			_ = w.queue.Nack(batch.ID, err.Error())
+			continue
+		}
+		_ = w.queue.Ack(batch.ID)
+	}
+
+	if w.client.metrics != nil {
+		if depthReporter, ok := w.client.metrics.(QueueDepthRecorder); ok {
+			if depth, err := w.queue.Depth(); err == nil {
+				if age, err := w.queue.OldestAge(); err == nil {
+					depthReporter.RecordQueueDepth(depth, age)
+				}
+			}
+		}
+	}
+}
+
+func (w *queueWorker) Close() {
+	close(w.stop)
+	<-w.done
+}
+
+// QueueDepthRecorder is an optional extension to MetricsCollector.
+// Implement it alongside MetricsCollector to receive queue depth and
+// oldest-item-age samples from a persistent queue worker.
+type QueueDepthRecorder interface {
+	RecordQueueDepth(depth int, oldestAge time.Duration)
+}
+
+// MemoryQueue is an in-memory Queue implementation. It does not survive
+// process restarts and is primarily useful for tests or short-lived
+// workloads where durability across crashes isn't required.
+type MemoryQueue struct {
+	mu      sync.Mutex
+	batches map[string]QueuedBatch
+	order   []string
+	seq     int64
+}
+
+// NewMemoryQueue creates an empty in-memory queue.
+func NewMemoryQueue() *MemoryQueue {
+	return &MemoryQueue{batches: make(map[string]QueuedBatch)}
+}
+
+// Enqueue implements Queue.
+func (q *MemoryQueue) Enqueue(records []UsageRecord) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.seq++
+	id := idFromSeq(q.seq)
+	q.batches[id] = QueuedBatch{ID: id, Records: records, QueuedAt: time.Now()}
+	q.order = append(q.order, id)
+	return nil
+}
+
+// Dequeue implements Queue.
+func (q *MemoryQueue) Dequeue(n int) ([]QueuedBatch, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if n > len(q.order) {
+		n = len(q.order)
+	}
+
+	out := make([]QueuedBatch, 0, n)
+	for _, id := range q.order[:n] {
+		out = append(out, q.batches[id])
+	}
+	return out, nil
+}
+
+// Ack implements Queue.
+func (q *MemoryQueue) Ack(batchID string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.batches, batchID)
+	q.removeFromOrder(batchID)
+	return nil
+}
+
+// Nack implements Queue.
+func (q *MemoryQueue) Nack(batchID string, reason string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if b, ok := q.batches[batchID]; ok {
+		b.Attempts++
+		q.batches[batchID] = b
+	}
+	return nil
+}
+
+// Depth implements Queue.
+func (q *MemoryQueue) Depth() (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.order), nil
+}
+
+// OldestAge implements Queue.
+func (q *MemoryQueue) OldestAge() (time.Duration, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.order) == 0 {
+		return 0, nil
+	}
+	oldest := q.batches[q.order[0]]
+	return time.Since(oldest.QueuedAt), nil
+}
+
+func (q *MemoryQueue) removeFromOrder(id string) {
+	for i, existing := range q.order {
+		if existing == id {
+			q.order = append(q.order[:i], q.order[i+1:]...)
+			return
+		}
+	}
+}
+
+func idFromSeq(seq int64) string {
+	const alphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+	if seq == 0 {
+		return "batch-0"
+	}
+	buf := make([]byte, 0, 16)
+	for seq > 0 {
+		buf = append([]byte{alphabet[seq%int64(len(alphabet))]}, buf...)
+		seq /= int64(len(alphabet))
+	}
+	return "batch-" + string(buf)
+}
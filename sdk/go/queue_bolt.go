@@ -0,0 +1,135 @@
+//go:build boltqueue
+
+package llmcostops
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var boltBucket = []byte("usage_queue")
+
+// BoltQueue is a Queue backed by a local BoltDB file, giving durability
+// across process restarts without requiring an external service. Build
+// with -tags boltqueue to include it.
+type BoltQueue struct {
+	db *bolt.DB
+}
+
+// NewBoltQueue opens (creating if necessary) a BoltDB-backed queue at path.
+func NewBoltQueue(path string) (*BoltQueue, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bolt queue: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize bolt queue bucket: %w", err)
+	}
+
+	return &BoltQueue{db: db}, nil
+}
+
+// Close releases the underlying BoltDB file handle.
+func (q *BoltQueue) Close() error {
+	return q.db.Close()
+}
+
+// Enqueue implements Queue.
+func (q *BoltQueue) Enqueue(records []UsageRecord) error {
+	return q.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(boltBucket)
+		id, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		batch := QueuedBatch{ID: fmt.Sprintf("batch-%d", id), Records: records, QueuedAt: time.Now()}
+		data, err := json.Marshal(batch)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(batch.ID), data)
+	})
+}
+
+// Dequeue implements Queue.
+func (q *BoltQueue) Dequeue(n int) ([]QueuedBatch, error) {
+	var out []QueuedBatch
+	err := q.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(boltBucket).Cursor()
+		for k, v := c.First(); k != nil && len(out) < n; k, v = c.Next() {
+			var batch QueuedBatch
+			if err := json.Unmarshal(v, &batch); err != nil {
+				continue
+			}
+			out = append(out, batch)
+		}
+		return nil
+	})
+	return out, err
+}
+
+// Ack implements Queue.
+func (q *BoltQueue) Ack(batchID string) error {
+	return q.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltBucket).Delete([]byte(batchID))
+	})
+}
+
+// Nack implements Queue.
+func (q *BoltQueue) Nack(batchID string, reason string) error {
+	return q.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(boltBucket)
+		data := b.Get([]byte(batchID))
+		if data == nil {
+			return nil
+		}
+		var batch QueuedBatch
+		if err := json.Unmarshal(data, &batch); err != nil {
+			return err
+		}
+		batch.Attempts++
+		updated, err := json.Marshal(batch)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(batchID), updated)
+	})
+}
+
+// Depth implements Queue.
+func (q *BoltQueue) Depth() (int, error) {
+	var n int
+	err := q.db.View(func(tx *bolt.Tx) error {
+		n = tx.Bucket(boltBucket).Stats().KeyN
+		return nil
+	})
+	return n, err
+}
+
+// OldestAge implements Queue.
+func (q *BoltQueue) OldestAge() (time.Duration, error) {
+	var age time.Duration
+	err := q.db.View(func(tx *bolt.Tx) error {
+		k, v := tx.Bucket(boltBucket).Cursor().First()
+		if k == nil {
+			return nil
+		}
+		var batch QueuedBatch
+		if err := json.Unmarshal(v, &batch); err != nil {
+			return err
+		}
+		age = time.Since(batch.QueuedAt)
+		return nil
+	})
+	return age, err
+}
@@ -0,0 +1,121 @@
+//go:build sqlitequeue
+
+package llmcostops
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteQueue is a Queue backed by a local SQLite database file. Build with
+// -tags sqlitequeue to include it.
+type SQLiteQueue struct {
+	db *sql.DB
+}
+
+// NewSQLiteQueue opens (creating if necessary) a SQLite-backed queue at
+// path.
+func NewSQLiteQueue(path string) (*SQLiteQueue, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite queue: %w", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS usage_queue (
+			id TEXT PRIMARY KEY,
+			records TEXT NOT NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			queued_at TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize sqlite queue schema: %w", err)
+	}
+
+	return &SQLiteQueue{db: db}, nil
+}
+
+// Close releases the underlying database handle.
+func (q *SQLiteQueue) Close() error {
+	return q.db.Close()
+}
+
+// Enqueue implements Queue.
+func (q *SQLiteQueue) Enqueue(records []UsageRecord) error {
+	data, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf("batch-%d", time.Now().UnixNano())
+	_, err = q.db.Exec(
+		`INSERT INTO usage_queue (id, records, attempts, queued_at) VALUES (?, ?, 0, ?)`,
+		id, string(data), time.Now(),
+	)
+	return err
+}
+
+// Dequeue implements Queue.
+func (q *SQLiteQueue) Dequeue(n int) ([]QueuedBatch, error) {
+	rows, err := q.db.Query(
+		`SELECT id, records, attempts, queued_at FROM usage_queue ORDER BY queued_at ASC LIMIT ?`, n,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []QueuedBatch
+	for rows.Next() {
+		var (
+			batch      QueuedBatch
+			recordsRaw string
+		)
+		if err := rows.Scan(&batch.ID, &recordsRaw, &batch.Attempts, &batch.QueuedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(recordsRaw), &batch.Records); err != nil {
+			return nil, err
+		}
+		out = append(out, batch)
+	}
+	return out, rows.Err()
+}
+
+// Ack implements Queue.
+func (q *SQLiteQueue) Ack(batchID string) error {
+	_, err := q.db.Exec(`DELETE FROM usage_queue WHERE id = ?`, batchID)
+	return err
+}
+
+// Nack implements Queue.
+func (q *SQLiteQueue) Nack(batchID string, reason string) error {
+	_, err := q.db.Exec(`UPDATE usage_queue SET attempts = attempts + 1 WHERE id = ?`, batchID)
+	return err
+}
+
+// Depth implements Queue.
+func (q *SQLiteQueue) Depth() (int, error) {
+	var n int
+	err := q.db.QueryRow(`SELECT COUNT(*) FROM usage_queue`).Scan(&n)
+	return n, err
+}
+
+// OldestAge implements Queue.
+func (q *SQLiteQueue) OldestAge() (time.Duration, error) {
+	var queuedAt sql.NullTime
+	err := q.db.QueryRow(`SELECT queued_at FROM usage_queue ORDER BY queued_at ASC LIMIT 1`).Scan(&queuedAt)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return time.Since(queuedAt.Time), nil
+}
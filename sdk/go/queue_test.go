@@ -0,0 +1,54 @@
+package llmcostops
+
+import "testing"
+
+func TestMemoryQueue_EnqueueDequeueAck(t *testing.T) {
+	q := NewMemoryQueue()
+
+	if err := q.Enqueue([]UsageRecord{{ID: "usage-1"}}); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	if err := q.Enqueue([]UsageRecord{{ID: "usage-2"}}); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	depth, err := q.Depth()
+	if err != nil {
+		t.Fatalf("Depth() error = %v", err)
+	}
+	if depth != 2 {
+		t.Errorf("Depth() = %d, want 2", depth)
+	}
+
+	batches, err := q.Dequeue(10)
+	if err != nil {
+		t.Fatalf("Dequeue() error = %v", err)
+	}
+	if len(batches) != 2 {
+		t.Fatalf("Dequeue() returned %d batches, want 2", len(batches))
+	}
+
+	if err := q.Ack(batches[0].ID); err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+
+	depth, _ = q.Depth()
+	if depth != 1 {
+		t.Errorf("Depth() after ack = %d, want 1", depth)
+	}
+}
+
+func TestMemoryQueue_Nack(t *testing.T) {
+	q := NewMemoryQueue()
+	_ = q.Enqueue([]UsageRecord{{ID: "usage-1"}})
+
+	batches, _ := q.Dequeue(1)
+	if err := q.Nack(batches[0].ID, "server unreachable"); err != nil {
+		t.Fatalf("Nack() error = %v", err)
+	}
+
+	batches, _ = q.Dequeue(1)
+	if batches[0].Attempts != 1 {
+		t.Errorf("Attempts = %d, want 1", batches[0].Attempts)
+	}
+}
@@ -0,0 +1,161 @@
+package llmcostops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+)
+
+// remoteWriteMetricName is the Prometheus series name RemoteWrite pushes
+// cost data under; per-record identity lives in labels, matching the
+// provider/model/project_id/organization_id labels FormatPrometheus and
+// FormatOpenMetrics render.
+const remoteWriteMetricName = "llm_cost_total_usd"
+
+// RemoteWriteBasicAuth carries HTTP Basic credentials for RemoteWrite.
+type RemoteWriteBasicAuth struct {
+	Username string
+	Password string
+}
+
+// RemoteWriteOptions configures RemoteWrite's push to a Prometheus
+// remote_write endpoint.
+type RemoteWriteOptions struct {
+	// BasicAuth, if set, is sent as an HTTP Basic Authorization header.
+	// Mutually exclusive with BearerToken.
+	BasicAuth *RemoteWriteBasicAuth
+
+	// BearerToken, if set, is sent as a Bearer Authorization header.
+	// Mutually exclusive with BasicAuth.
+	BearerToken string
+
+	// Timeout bounds the remote_write POST. Defaults to 30s.
+	Timeout time.Duration
+}
+
+func (o *RemoteWriteOptions) withDefaults() RemoteWriteOptions {
+	if o == nil {
+		return RemoteWriteOptions{Timeout: 30 * time.Second}
+	}
+	opts := *o
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+	return opts
+}
+
+// RemoteWrite fetches cost records matching params and pushes them to a
+// Prometheus remote_write endpoint as snappy-compressed protobuf, so
+// operators can pipe LLM cost data into their existing
+// Prometheus/Grafana/Thanos/Mimir stack alongside infra metrics.
+func (s *ExportService) RemoteWrite(ctx context.Context, params *ExportParams, endpoint string, opts *RemoteWriteOptions) error {
+	if params == nil {
+		return fmt.Errorf("%w: params cannot be nil", ErrBadRequest)
+	}
+	if endpoint == "" {
+		return fmt.Errorf("%w: endpoint is required", ErrBadRequest)
+	}
+	options := opts.withDefaults()
+	if opts != nil && opts.BasicAuth != nil && opts.BearerToken != "" {
+		return fmt.Errorf("%w: BasicAuth and BearerToken are mutually exclusive", ErrBadRequest)
+	}
+
+	fetchParams := *params
+	fetchParams.Format = FormatJSON
+
+	data, err := s.Export(ctx, &fetchParams)
+	if err != nil {
+		return err
+	}
+
+	var records []CostRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return fmt.Errorf("%w: decoding export for remote_write: %v", ErrBadRequest, err)
+	}
+
+	writeReq, err := buildRemoteWriteRequest(records, params)
+	if err != nil {
+		return err
+	}
+
+	body, err := writeReq.Marshal()
+	if err != nil {
+		return fmt.Errorf("encoding remote_write request: %w", err)
+	}
+	compressed := snappy.Encode(nil, body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(compressed))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if options.BasicAuth != nil {
+		req.SetBasicAuth(options.BasicAuth.Username, options.BasicAuth.Password)
+	} else if options.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+options.BearerToken)
+	}
+
+	httpClient := s.client.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	client := *httpClient
+	client.Timeout = options.Timeout
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("remote_write request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return s.client.checkResponseStatus(resp.StatusCode, nil)
+	}
+	return nil
+}
+
+// buildRemoteWriteRequest converts records into a prompb.WriteRequest,
+// labeling each sample with provider, model, and the organization/project
+// scope the export was requested under. Labels are sorted lexicographically
+// by name, as the remote_write spec requires and strict receivers (
+// Prometheus, Mimir, Thanos) enforce.
+func buildRemoteWriteRequest(records []CostRecord, params *ExportParams) (*prompb.WriteRequest, error) {
+	series := make([]prompb.TimeSeries, 0, len(records))
+	for _, rec := range records {
+		cost, err := NewDecimalFromString(rec.TotalCost)
+		if err != nil {
+			return nil, err
+		}
+		value, _ := cost.Float64()
+
+		labels := []prompb.Label{
+			{Name: "__name__", Value: remoteWriteMetricName},
+			{Name: "provider", Value: string(rec.Provider)},
+			{Name: "model", Value: rec.Model},
+		}
+		if params.OrganizationID != "" {
+			labels = append(labels, prompb.Label{Name: "organization_id", Value: params.OrganizationID})
+		}
+		if params.ProjectID != "" {
+			labels = append(labels, prompb.Label{Name: "project_id", Value: params.ProjectID})
+		}
+		sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+
+		series = append(series, prompb.TimeSeries{
+			Labels: labels,
+			Samples: []prompb.Sample{
+				{Value: value, Timestamp: rec.Timestamp.UnixMilli()},
+			},
+		})
+	}
+	return &prompb.WriteRequest{Timeseries: series}, nil
+}
@@ -0,0 +1,121 @@
+package llmcostops
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+)
+
+func TestBuildRemoteWriteRequest_LabelsSeries(t *testing.T) {
+	records := []CostRecord{
+		{Provider: ProviderOpenAI, Model: "gpt-4o", TotalCost: "1.50", Timestamp: time.Unix(1700000000, 0)},
+	}
+	params := &ExportParams{OrganizationID: "org-1", ProjectID: "proj-1"}
+
+	req, err := buildRemoteWriteRequest(records, params)
+	if err != nil {
+		t.Fatalf("buildRemoteWriteRequest() error = %v", err)
+	}
+	if len(req.Timeseries) != 1 {
+		t.Fatalf("len(Timeseries) = %d, want 1", len(req.Timeseries))
+	}
+
+	labels := map[string]string{}
+	for _, l := range req.Timeseries[0].Labels {
+		labels[l.Name] = l.Value
+	}
+	if labels["__name__"] != remoteWriteMetricName || labels["provider"] != string(ProviderOpenAI) ||
+		labels["model"] != "gpt-4o" || labels["organization_id"] != "org-1" || labels["project_id"] != "proj-1" {
+		t.Errorf("labels = %+v", labels)
+	}
+
+	if got := req.Timeseries[0].Samples[0].Value; got != 1.5 {
+		t.Errorf("Samples[0].Value = %v, want 1.5", got)
+	}
+}
+
+func TestBuildRemoteWriteRequest_RejectsInvalidCost(t *testing.T) {
+	records := []CostRecord{{TotalCost: "not-a-number"}}
+	if _, err := buildRemoteWriteRequest(records, &ExportParams{}); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestExportService_RemoteWrite_PushesCompressedSeries(t *testing.T) {
+	exportServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		records := []CostRecord{
+			{Provider: ProviderOpenAI, Model: "gpt-4o", TotalCost: "2.00", Timestamp: time.Now()},
+		}
+		json.NewEncoder(w).Encode(records)
+	}))
+	defer exportServer.Close()
+
+	var gotAuth, gotEncoding string
+	var gotSeries int
+	remoteWriteServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotEncoding = r.Header.Get("Content-Encoding")
+
+		compressed, _ := io.ReadAll(r.Body)
+		decompressed, err := snappy.Decode(nil, compressed)
+		if err != nil {
+			t.Errorf("snappy.Decode() error = %v", err)
+		}
+		var writeReq prompb.WriteRequest
+		if err := writeReq.Unmarshal(decompressed); err != nil {
+			t.Errorf("writeReq.Unmarshal() error = %v", err)
+		}
+		gotSeries = len(writeReq.Timeseries)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer remoteWriteServer.Close()
+
+	client := setupTestClient(t, exportServer.URL)
+	defer client.Close()
+
+	err := client.Export.RemoteWrite(context.Background(), &ExportParams{}, remoteWriteServer.URL, &RemoteWriteOptions{
+		BearerToken: "remote-token",
+	})
+	if err != nil {
+		t.Fatalf("RemoteWrite() error = %v", err)
+	}
+	if gotAuth != "Bearer remote-token" {
+		t.Errorf("Authorization = %q, want Bearer remote-token", gotAuth)
+	}
+	if gotEncoding != "snappy" {
+		t.Errorf("Content-Encoding = %q, want snappy", gotEncoding)
+	}
+	if gotSeries != 1 {
+		t.Errorf("len(Timeseries) = %d, want 1", gotSeries)
+	}
+}
+
+func TestExportService_RemoteWrite_RejectsBothAuthModes(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	err := client.Export.RemoteWrite(context.Background(), &ExportParams{}, "http://example.invalid/push", &RemoteWriteOptions{
+		BasicAuth:   &RemoteWriteBasicAuth{Username: "u", Password: "p"},
+		BearerToken: "t",
+	})
+	if !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestExportService_RemoteWrite_RequiresEndpoint(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	if err := client.Export.RemoteWrite(context.Background(), &ExportParams{}, "", nil); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
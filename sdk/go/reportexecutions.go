@@ -0,0 +1,144 @@
+package llmcostops
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ReportExecution is a single past run of a ScheduledReport.
+type ReportExecution struct {
+	ID              string     `json:"id"`
+	ScheduleID      string     `json:"schedule_id"`
+	Status          string     `json:"status"` // "succeeded", "failed"
+	StartedAt       time.Time  `json:"started_at"`
+	FinishedAt      *time.Time `json:"finished_at,omitempty"`
+	DurationMs      int64      `json:"duration_ms"`
+	DeliveryStatus  string     `json:"delivery_status,omitempty"`
+	DeliveryError   string     `json:"delivery_error,omitempty"`
+	OutputSizeBytes int64      `json:"output_size_bytes,omitempty"`
+}
+
+// ReportExecutionFilter narrows ListReportExecutions.
+type ReportExecutionFilter struct {
+	Status string `json:"status,omitempty"`
+	PaginationParams
+}
+
+// ListReportExecutions lists past runs of the scheduled report scheduleID,
+// most recent first.
+func (s *ExportService) ListReportExecutions(ctx context.Context, scheduleID string, filter *ReportExecutionFilter) ([]ReportExecution, error) {
+	if scheduleID == "" {
+		return nil, fmt.Errorf("%w: scheduleID is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/export/schedule/%s/executions", url.PathEscape(scheduleID))
+	req, err := s.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter != nil {
+		q := req.URL.Query()
+		if filter.Status != "" {
+			q.Set("status", filter.Status)
+		}
+		if filter.Page > 0 {
+			q.Set("page", fmt.Sprintf("%d", filter.Page))
+		}
+		if filter.PageSize > 0 {
+			q.Set("page_size", fmt.Sprintf("%d", filter.PageSize))
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	var result []ReportExecution
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PurgeParams configures PurgeReportHistory. At least one of OlderThan or
+// KeepLast should be set; leaving both zero would purge every execution.
+type PurgeParams struct {
+	// OlderThan deletes executions whose StartedAt is older than this
+	// duration before now.
+	OlderThan time.Duration `json:"older_than,omitempty"`
+
+	// KeepLast retains the KeepLast most recent executions per schedule,
+	// regardless of OlderThan.
+	KeepLast int `json:"keep_last,omitempty"`
+
+	// ReportType restricts the purge to schedules of this report type
+	// ("cost", "usage", "forecast", "audit"). Empty means all types.
+	ReportType string `json:"report_type,omitempty"`
+
+	// DryRun, if true, reports how many executions would be deleted
+	// without deleting them.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// PurgeJobStatus is the lifecycle state of an asynchronous
+// PurgeReportHistory job.
+type PurgeJobStatus string
+
+const (
+	PurgeJobPending   PurgeJobStatus = "pending"
+	PurgeJobRunning   PurgeJobStatus = "running"
+	PurgeJobSucceeded PurgeJobStatus = "succeeded"
+	PurgeJobFailed    PurgeJobStatus = "failed"
+)
+
+// PurgeJob is the current state of an asynchronous report-history purge,
+// following the same start-then-poll shape as ExportJob since large
+// deletes shouldn't block the caller.
+type PurgeJob struct {
+	ID           string         `json:"id"`
+	Status       PurgeJobStatus `json:"status"`
+	DryRun       bool           `json:"dry_run"`
+	DeletedCount int64          `json:"deleted_count"`
+	Error        string         `json:"error,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+// PurgeReportHistory starts an asynchronous purge of scheduled-report
+// execution history matching params and returns the job's initial state;
+// poll it with GetPurgeJob.
+func (s *ExportService) PurgeReportHistory(ctx context.Context, params *PurgeParams) (*PurgeJob, error) {
+	if params == nil {
+		return nil, fmt.Errorf("%w: params cannot be nil", ErrBadRequest)
+	}
+
+	req, err := s.client.newRequest(http.MethodPost, "/api/v1/export/schedule/executions/purge", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result PurgeJob
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPurgeJob retrieves a PurgeReportHistory job's current state by ID.
+func (s *ExportService) GetPurgeJob(ctx context.Context, id string) (*PurgeJob, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: id is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/export/schedule/executions/purge/%s", url.PathEscape(id))
+	req, err := s.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result PurgeJob
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
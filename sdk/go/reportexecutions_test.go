@@ -0,0 +1,74 @@
+package llmcostops
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestExportService_ListReportExecutions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/export/schedule/sched-1/executions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("status"); got != "failed" {
+			t.Errorf("status query = %q, want failed", got)
+		}
+		json.NewEncoder(w).Encode([]ReportExecution{{ID: "exec-1", ScheduleID: "sched-1", Status: "failed"}})
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	executions, err := client.Export.ListReportExecutions(context.Background(), "sched-1", &ReportExecutionFilter{Status: "failed"})
+	if err != nil {
+		t.Fatalf("ListReportExecutions() error = %v", err)
+	}
+	if len(executions) != 1 || executions[0].ID != "exec-1" {
+		t.Errorf("ListReportExecutions() = %+v", executions)
+	}
+}
+
+func TestExportService_ListReportExecutions_RequiresScheduleID(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	if _, err := client.Export.ListReportExecutions(context.Background(), "", nil); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestExportService_PurgeReportHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body PurgeParams
+		json.NewDecoder(r.Body).Decode(&body)
+		if !body.DryRun || body.KeepLast != 10 {
+			t.Errorf("request body = %+v", body)
+		}
+		json.NewEncoder(w).Encode(PurgeJob{ID: "purge-1", Status: PurgeJobPending, DryRun: true})
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	job, err := client.Export.PurgeReportHistory(context.Background(), &PurgeParams{KeepLast: 10, DryRun: true})
+	if err != nil {
+		t.Fatalf("PurgeReportHistory() error = %v", err)
+	}
+	if job.ID != "purge-1" || job.Status != PurgeJobPending {
+		t.Errorf("PurgeReportHistory() = %+v", job)
+	}
+}
+
+func TestExportService_GetPurgeJob_RequiresID(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	if _, err := client.Export.GetPurgeJob(context.Background(), ""); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package llmcostops
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how Client.do retries failed requests: how long to
+// wait between attempts, how many attempts to make, and which failures are
+// worth retrying at all.
+type RetryPolicy struct {
+	// MinRetryDelay is the base delay before the first retry.
+	MinRetryDelay time.Duration
+
+	// MaxRetryDelay caps the computed delay before jitter is applied.
+	MaxRetryDelay time.Duration
+
+	// MaxRetries is the maximum number of retry attempts after the initial
+	// request.
+	MaxRetries int
+
+	// Multiplier is applied to the delay after each attempt:
+	// delay = min(MaxRetryDelay, MinRetryDelay * Multiplier^attempt).
+	Multiplier float64
+
+	// Jitter, when true, applies full jitter (rand.Float64() * delay) to
+	// the computed delay before sleeping.
+	Jitter bool
+
+	// RetryableStatusCodes lists HTTP status codes that should be retried
+	// in addition to the default 429/502/503/504.
+	RetryableStatusCodes []int
+
+	// RetryableFn, if set, overrides the default retryability check for a
+	// given error. Return true to retry.
+	RetryableFn func(err error) bool
+}
+
+// DefaultRetryPolicy returns the policy used when no RetryPolicy option is
+// supplied: full-jitter exponential backoff starting at baseDelay (500ms if
+// baseDelay is not positive), doubling up to 30s, for maxRetries retries.
+// NewClient passes its configured RetryDelay, 1s by default.
+func DefaultRetryPolicy(maxRetries int, baseDelay time.Duration) RetryPolicy {
+	if baseDelay <= 0 {
+		baseDelay = 500 * time.Millisecond
+	}
+	return RetryPolicy{
+		MinRetryDelay:        baseDelay,
+		MaxRetryDelay:        30 * time.Second,
+		MaxRetries:           maxRetries,
+		Multiplier:           2,
+		Jitter:               true,
+		RetryableStatusCodes: []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+	}
+}
+
+// WithRetryPolicy overrides the client's retry behavior. Overrides
+// WithMaxRetries/WithRetryDelay when used together; whichever is applied
+// last wins.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Config) error {
+		c.RetryPolicy = &policy
+		return nil
+	}
+}
+
+// delay computes the backoff for the given zero-based attempt, applying
+// full jitter if enabled. retryAfter, when non-zero, takes precedence over
+// the computed delay (honoring a server's Retry-After header).
+func (p RetryPolicy) delay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+
+	mult := 1.0
+	for i := 0; i < attempt; i++ {
+		mult *= p.Multiplier
+	}
+
+	d := time.Duration(float64(p.MinRetryDelay) * mult)
+	if d > p.MaxRetryDelay {
+		d = p.MaxRetryDelay
+	}
+
+	if p.Jitter {
+		d = time.Duration(rand.Float64() * float64(d))
+	}
+	return d
+}
+
+func (p RetryPolicy) retryableStatus(code int) bool {
+	for _, c := range p.RetryableStatusCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// retryableNetworkError reports whether err represents a transient network
+// failure worth retrying: a net.Error marked Timeout/Temporary, io.EOF or
+// io.ErrUnexpectedEOF, or any *net.OpError (a connection reset, a refused
+// dial, and other socket-level failures).
+func retryableNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() || netErr.Temporary() {
+			return true
+		}
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+
+	return false
+}
+
+// parseRetryAfter parses the Retry-After header in either its delta-seconds
+// or HTTP-date form, per RFC 7231 §7.1.3. Returns zero if the header is
+// absent or unparseable.
+func parseRetryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,314 @@
+package llmcostops
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RulesService manages budget and threshold-alert rule definitions and
+// their evaluation, in the spirit of the Prometheus/Thanos rules API but
+// expressed in cost-ops terms.
+type RulesService struct {
+	client *Client
+}
+
+// BudgetWindow is the rolling or calendar-aligned window a BudgetRule is
+// evaluated over.
+type BudgetWindow string
+
+const (
+	BudgetWindowDaily   BudgetWindow = "daily"
+	BudgetWindowWeekly  BudgetWindow = "weekly"
+	BudgetWindowMonthly BudgetWindow = "monthly"
+)
+
+// BudgetRule binds a filter to a spend limit over a window, with soft and
+// hard thresholds expressed as a fraction of Limit (e.g. 0.8, 1.0).
+type BudgetRule struct {
+	ID            string       `json:"id,omitempty"`
+	Name          string       `json:"name"`
+	Filters       Filters      `json:"filters"`
+	Limit         float64      `json:"limit"`
+	LimitUnit     string       `json:"limit_unit"` // "usd" or "tokens"
+	Window        BudgetWindow `json:"window"`
+	SoftThreshold float64      `json:"soft_threshold"`
+	HardThreshold float64      `json:"hard_threshold"`
+	Notifiers     []Notifier   `json:"notifiers,omitempty"`
+	Enabled       bool         `json:"enabled"`
+}
+
+// ThresholdAlertRule fires when a StatsRange-style expression crosses a
+// threshold for a sustained duration, producing Alert objects with the same
+// pending/firing/resolved lifecycle Prometheus alerting rules use.
+type ThresholdAlertRule struct {
+	ID          string            `json:"id,omitempty"`
+	Name        string            `json:"name"`
+	Expr        string            `json:"expr"`
+	For         time.Duration     `json:"for"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Notifiers   []Notifier        `json:"notifiers,omitempty"`
+	Enabled     bool              `json:"enabled"`
+}
+
+// Notifier configures where a fired rule is delivered.
+type Notifier struct {
+	// Type is one of "slack", "pagerduty", or "webhook".
+	Type   string `json:"type"`
+	Target string `json:"target"`
+}
+
+// AlertState is the lifecycle state of a fired Alert.
+type AlertState string
+
+const (
+	AlertStatePending  AlertState = "pending"
+	AlertStateFiring   AlertState = "firing"
+	AlertStateResolved AlertState = "resolved"
+)
+
+// Alert is a single firing instance of a ThresholdAlertRule or BudgetRule.
+type Alert struct {
+	RuleID      string            `json:"rule_id"`
+	State       AlertState        `json:"state"`
+	ActiveAt    time.Time         `json:"active_at"`
+	Value       float64           `json:"value"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// CreateBudgetRule creates a new budget rule.
+func (s *RulesService) CreateBudgetRule(ctx context.Context, rule *BudgetRule) (*BudgetRule, error) {
+	if rule == nil || rule.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrBadRequest)
+	}
+
+	req, err := s.client.newRequest(http.MethodPost, "/api/v1/rules/budgets", rule)
+	if err != nil {
+		return nil, err
+	}
+
+	var result BudgetRule
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateBudgetRule updates an existing budget rule.
+func (s *RulesService) UpdateBudgetRule(ctx context.Context, id string, rule *BudgetRule) (*BudgetRule, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: id is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/rules/budgets/%s", url.PathEscape(id))
+	req, err := s.client.newRequest(http.MethodPut, path, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	var result BudgetRule
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteBudgetRule deletes a budget rule by ID.
+func (s *RulesService) DeleteBudgetRule(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: id is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/rules/budgets/%s", url.PathEscape(id))
+	req, err := s.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	return s.client.do(ctx, req, nil)
+}
+
+// GetBudgetRule retrieves a budget rule by ID.
+func (s *RulesService) GetBudgetRule(ctx context.Context, id string) (*BudgetRule, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: id is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/rules/budgets/%s", url.PathEscape(id))
+	req, err := s.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result BudgetRule
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListBudgetRules lists all budget rules.
+func (s *RulesService) ListBudgetRules(ctx context.Context) ([]BudgetRule, error) {
+	req, err := s.client.newRequest(http.MethodGet, "/api/v1/rules/budgets", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []BudgetRule
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateAlertRule creates a new threshold alert rule.
+func (s *RulesService) CreateAlertRule(ctx context.Context, rule *ThresholdAlertRule) (*ThresholdAlertRule, error) {
+	if rule == nil || rule.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrBadRequest)
+	}
+	if rule.Expr == "" {
+		return nil, fmt.Errorf("%w: expr is required", ErrBadRequest)
+	}
+
+	req, err := s.client.newRequest(http.MethodPost, "/api/v1/rules/alerts", rule)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ThresholdAlertRule
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateAlertRule updates an existing threshold alert rule.
+func (s *RulesService) UpdateAlertRule(ctx context.Context, id string, rule *ThresholdAlertRule) (*ThresholdAlertRule, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: id is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/rules/alerts/%s", url.PathEscape(id))
+	req, err := s.client.newRequest(http.MethodPut, path, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ThresholdAlertRule
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteAlertRule deletes a threshold alert rule by ID.
+func (s *RulesService) DeleteAlertRule(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: id is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/rules/alerts/%s", url.PathEscape(id))
+	req, err := s.client.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	return s.client.do(ctx, req, nil)
+}
+
+// GetAlertRule retrieves a threshold alert rule by ID.
+func (s *RulesService) GetAlertRule(ctx context.Context, id string) (*ThresholdAlertRule, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: id is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/rules/alerts/%s", url.PathEscape(id))
+	req, err := s.client.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ThresholdAlertRule
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListAlertRules lists all threshold alert rules.
+func (s *RulesService) ListAlertRules(ctx context.Context) ([]ThresholdAlertRule, error) {
+	req, err := s.client.newRequest(http.MethodGet, "/api/v1/rules/alerts", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ThresholdAlertRule
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Alerts returns all currently-firing alerts across every rule.
+func (s *RulesService) Alerts(ctx context.Context) ([]Alert, error) {
+	req, err := s.client.newRequest(http.MethodGet, "/api/v1/rules/firing", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Alert
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ForecastBudgetRule fetches the budget rule identified by ruleID and
+// projects its spend through the end of its window using a
+// BudgetForecaster built with DefaultForecastAlpha over history — one
+// CostSummary per elapsed bucket of the rule's Window so far, oldest
+// first, scoped to the rule's Filters by the caller. Unlike Evaluate,
+// which asks the server to re-run its own scheduled evaluation, this
+// forecast runs entirely client-side; when it projects a breach of
+// SoftThreshold or HardThreshold it emits EventBudgetThresholdCrossed
+// alongside the server-observed alerts Evaluate and Alerts surface.
+func (s *RulesService) ForecastBudgetRule(ctx context.Context, ruleID string, history []CostSummary) (*BudgetForecast, error) {
+	rule, err := s.GetBudgetRule(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast, err := NewBudgetForecaster().Forecast(*rule, history)
+	if err != nil {
+		return nil, err
+	}
+
+	if forecast.WillBreachSoft || forecast.WillBreachHard {
+		s.client.Webhooks.emit(EventBudgetThresholdCrossed, forecast)
+	}
+
+	return forecast, nil
+}
+
+// Evaluate forces immediate evaluation of a single rule (budget or
+// threshold alert), rather than waiting for the server's next scheduled
+// evaluation cycle.
+func (s *RulesService) Evaluate(ctx context.Context, ruleID string) (*Alert, error) {
+	if ruleID == "" {
+		return nil, fmt.Errorf("%w: rule ID is required", ErrBadRequest)
+	}
+
+	path := fmt.Sprintf("/api/v1/rules/%s/evaluate", url.PathEscape(ruleID))
+	req, err := s.client.newRequest(http.MethodPost, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Alert
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
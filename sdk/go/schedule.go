@@ -0,0 +1,91 @@
+package llmcostops
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// quartzParser additionally accepts a leading seconds field (6-field cron),
+// and shorthandParser accepts descriptors (@hourly, @daily, @weekly,
+// @monthly) and @every <duration> alongside a standard 5-field expression.
+// Plain 5-field expressions are parsed with the package-level cronParser
+// (see scheduler.go) so ScheduleSpec and RunLocalScheduler agree on what a
+// bare cron string means.
+var (
+	quartzParser    = cron.NewParser(cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	shorthandParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+)
+
+// ScheduleSpec is a parsed, ready-to-evaluate cron schedule. Use
+// ParseSchedule to validate a schedule string client-side before handing it
+// to ScheduleReport, and Next/NextN to preview its upcoming run times.
+type ScheduleSpec struct {
+	raw      string
+	schedule cron.Schedule
+}
+
+// ParseSchedule parses expr, trying standard 5-field cron, 6-field quartz
+// (leading seconds field), and shorthand (@hourly, @daily, @weekly,
+// @monthly, @every 15m) in turn. It returns ErrBadRequest wrapping the
+// underlying parser's message if none of them accept expr.
+func ParseSchedule(expr string) (*ScheduleSpec, error) {
+	trimmed := strings.TrimSpace(expr)
+	if trimmed == "" {
+		return nil, fmt.Errorf("%w: schedule is required", ErrBadRequest)
+	}
+
+	if strings.HasPrefix(trimmed, "@") {
+		schedule, err := shorthandParser.Parse(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("%w: parsing schedule %q: %v", ErrBadRequest, expr, err)
+		}
+		return &ScheduleSpec{raw: expr, schedule: schedule}, nil
+	}
+
+	parser := cronParser
+	if len(strings.Fields(trimmed)) == 6 {
+		parser = quartzParser
+	}
+
+	schedule, err := parser.Parse(trimmed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: parsing schedule %q: %v", ErrBadRequest, expr, err)
+	}
+	return &ScheduleSpec{raw: expr, schedule: schedule}, nil
+}
+
+// String returns the expression ParseSchedule was given.
+func (s *ScheduleSpec) String() string { return s.raw }
+
+// Next returns the first run time strictly after after.
+func (s *ScheduleSpec) Next(after time.Time) time.Time {
+	return s.schedule.Next(after)
+}
+
+// NextN returns the next n run times strictly after after, oldest first.
+// A non-positive n returns nil.
+func (s *ScheduleSpec) NextN(after time.Time, n int) []time.Time {
+	if n <= 0 {
+		return nil
+	}
+	times := make([]time.Time, 0, n)
+	cursor := after
+	for i := 0; i < n; i++ {
+		cursor = s.schedule.Next(cursor)
+		times = append(times, cursor)
+	}
+	return times
+}
+
+// PreviewSchedule parses spec and returns its next n run times after now,
+// so a caller can show upcoming runs before saving a ScheduleReport call.
+func (s *ExportService) PreviewSchedule(spec string, n int) ([]time.Time, error) {
+	parsed, err := ParseSchedule(spec)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.NextN(time.Now(), n), nil
+}
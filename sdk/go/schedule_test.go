@@ -0,0 +1,123 @@
+package llmcostops
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseSchedule_Standard(t *testing.T) {
+	spec, err := ParseSchedule("0 9 * * 1")
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+
+	after := time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC) // a Monday
+	next := spec.Next(after)
+	if next.Weekday() != time.Monday || next.Hour() != 9 {
+		t.Errorf("Next() = %v, want next Monday at 09:00", next)
+	}
+}
+
+func TestParseSchedule_Quartz(t *testing.T) {
+	spec, err := ParseSchedule("30 0 9 * * 1")
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+
+	next := spec.Next(time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC))
+	if next.Second() != 30 {
+		t.Errorf("Next().Second() = %d, want 30", next.Second())
+	}
+}
+
+func TestParseSchedule_Shorthand(t *testing.T) {
+	spec, err := ParseSchedule("@every 15m")
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+
+	after := time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC)
+	next := spec.Next(after)
+	if got := next.Sub(after); got != 15*time.Minute {
+		t.Errorf("Next() - after = %v, want 15m", got)
+	}
+}
+
+func TestParseSchedule_RejectsInvalidExpression(t *testing.T) {
+	if _, err := ParseSchedule("not a cron expression"); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestParseSchedule_RejectsEmpty(t *testing.T) {
+	if _, err := ParseSchedule("  "); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestScheduleSpec_NextN(t *testing.T) {
+	spec, err := ParseSchedule("0 0 * * *")
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+
+	after := time.Date(2026, 7, 20, 12, 0, 0, 0, time.UTC)
+	times := spec.NextN(after, 3)
+	if len(times) != 3 {
+		t.Fatalf("NextN() returned %d times, want 3", len(times))
+	}
+	for i, tm := range times {
+		if tm.Hour() != 0 || tm.Minute() != 0 {
+			t.Errorf("times[%d] = %v, want midnight", i, tm)
+		}
+		if i > 0 && !tm.After(times[i-1]) {
+			t.Errorf("times[%d] = %v is not after times[%d] = %v", i, tm, i-1, times[i-1])
+		}
+	}
+}
+
+func TestScheduleSpec_NextN_NonPositive(t *testing.T) {
+	spec, err := ParseSchedule("0 0 * * *")
+	if err != nil {
+		t.Fatalf("ParseSchedule() error = %v", err)
+	}
+	if got := spec.NextN(time.Now(), 0); got != nil {
+		t.Errorf("NextN(0) = %v, want nil", got)
+	}
+}
+
+func TestExportService_PreviewSchedule(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	times, err := client.Export.PreviewSchedule("0 0 * * *", 2)
+	if err != nil {
+		t.Fatalf("PreviewSchedule() error = %v", err)
+	}
+	if len(times) != 2 {
+		t.Errorf("len(times) = %d, want 2", len(times))
+	}
+}
+
+func TestExportService_PreviewSchedule_RejectsInvalidSpec(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	if _, err := client.Export.PreviewSchedule("bogus", 2); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestExportService_ScheduleReport_RejectsInvalidSchedule(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	_, err := client.Export.ScheduleReport(context.Background(), &ReportScheduleParams{
+		Name:     "weekly-cost",
+		Schedule: "not a cron expression",
+	})
+	if !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package llmcostops
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Deliverer hands a generated report's bytes off to a destination. It is
+// invoked once per due schedule after ExportService.Export succeeds.
+type Deliverer interface {
+	Deliver(ctx context.Context, report *ScheduledReport, data []byte) error
+}
+
+// LeaderElector decides whether the current process is allowed to execute
+// due jobs. The default no-op elector always returns true, which is correct
+// for single-instance deployments; multi-replica deployments should supply
+// an implementation backed by their own coordination primitive (e.g. a
+// database lease or etcd/Consul lock) so only one replica runs each job.
+type LeaderElector interface {
+	IsLeader(ctx context.Context) (bool, error)
+}
+
+type noopLeaderElector struct{}
+
+func (noopLeaderElector) IsLeader(context.Context) (bool, error) { return true, nil }
+
+// LocalSchedulerOptions configures RunLocalScheduler.
+type LocalSchedulerOptions struct {
+	// PollInterval controls how often the scheduler re-fetches the user's
+	// scheduled report definitions from the server. Defaults to 1 minute.
+	PollInterval time.Duration
+
+	// Deliverers maps a ReportScheduleParams.DeliveryMethod value (e.g.
+	// "email", "storage", "webhook", "slack") to the Deliverer that should
+	// handle it. A schedule whose delivery method has no registered
+	// Deliverer is skipped and the error is passed to OnError.
+	Deliverers map[string]Deliverer
+
+	// LeaderElector gates whether this process executes due jobs. Defaults
+	// to a no-op elector that always reports leadership.
+	LeaderElector LeaderElector
+
+	// OnError is called with any error encountered while evaluating or
+	// running a schedule; it must not block. May be nil.
+	OnError func(report *ScheduledReport, err error)
+}
+
+func (o LocalSchedulerOptions) withDefaults() LocalSchedulerOptions {
+	if o.PollInterval <= 0 {
+		o.PollInterval = time.Minute
+	}
+	if o.LeaderElector == nil {
+		o.LeaderElector = noopLeaderElector{}
+	}
+	return o
+}
+
+// RunLocalScheduler runs an in-process cron scheduler that periodically
+// pulls the user's scheduled report definitions, evaluates their cron
+// expressions, and invokes ExportService.Export when a schedule is due,
+// dispatching the result to the Deliverer registered for its delivery
+// method. It blocks until ctx is canceled, making it suitable for
+// self-hosted or air-gapped deployments that can't rely on the server-side
+// scheduler.
+//
+// Only one replica should execute a given job at a time in multi-replica
+// deployments; supply LocalSchedulerOptions.LeaderElector to enforce that.
+func (s *ExportService) RunLocalScheduler(ctx context.Context, opts LocalSchedulerOptions) error {
+	opts = opts.withDefaults()
+
+	lastRun := make(map[string]time.Time)
+
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+
+	run := func() {
+		isLeader, err := opts.LeaderElector.IsLeader(ctx)
+		if err != nil || !isLeader {
+			return
+		}
+
+		reports, err := s.ListScheduledReports(ctx)
+		if err != nil {
+			if opts.OnError != nil {
+				opts.OnError(nil, fmt.Errorf("list scheduled reports: %w", err))
+			}
+			return
+		}
+
+		now := time.Now()
+		for i := range reports {
+			report := &reports[i]
+			if !report.Enabled {
+				continue
+			}
+
+			schedule, err := ParseSchedule(report.Schedule)
+			if err != nil {
+				if opts.OnError != nil {
+					opts.OnError(report, fmt.Errorf("parse schedule: %w", err))
+				}
+				continue
+			}
+
+			since := lastRun[report.ID]
+			if since.IsZero() {
+				since = now.Add(-opts.PollInterval)
+			}
+			if schedule.Next(since).After(now) {
+				continue
+			}
+
+			lastRun[report.ID] = now
+
+			deliverer, ok := opts.Deliverers[report.DeliveryMethod]
+			if !ok {
+				if opts.OnError != nil {
+					opts.OnError(report, fmt.Errorf("no deliverer registered for delivery method %q", report.DeliveryMethod))
+				}
+				continue
+			}
+
+			data, err := s.Export(ctx, &ExportParams{
+				Format:         report.Format,
+				OrganizationID: report.OrganizationID,
+				ProjectID:      report.ProjectID,
+			})
+			if err != nil {
+				if opts.OnError != nil {
+					opts.OnError(report, fmt.Errorf("export: %w", err))
+				}
+				continue
+			}
+
+			if err := deliverer.Deliver(ctx, report, data); err != nil {
+				if opts.OnError != nil {
+					opts.OnError(report, fmt.Errorf("deliver: %w", err))
+				}
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			run()
+		}
+	}
+}
+
+// cronParser is the standard 5-field parser ScheduleSpec (see schedule.go)
+// uses for bare cron expressions, so RunLocalScheduler's Next-run evaluation
+// above and ParseSchedule agree on what a given schedule string means.
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
@@ -0,0 +1,336 @@
+package llmcostops
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// UsageStreamParams filters UsageService.Stream. Unlike UsageListParams,
+// which pages through a point-in-time snapshot via Page/PageSize,
+// streaming resumes from an exact position via AfterID: if a connection
+// drops mid-stream, call Stream again with AfterID set to the ID of the
+// last UsageRecord the caller successfully processed, rather than
+// restarting from the beginning.
+type UsageStreamParams struct {
+	Range          TimeRange
+	StartTime      *time.Time
+	EndTime        *time.Time
+	OrganizationID string
+	ProjectID      string
+	Provider       Provider
+	Model          string
+
+	// AfterID resumes the stream immediately after the UsageRecord with
+	// this ID.
+	AfterID string
+
+	// ReadDeadline, if non-zero, fails the stream with ErrContextCanceled
+	// if no record (or the end of the stream) arrives before this instant.
+	ReadDeadline time.Time
+}
+
+// Stream returns a channel of UsageRecords matching params, delivered as
+// they're received over a single long-lived NDJSON connection, and a
+// channel carrying at most one error: nil on a clean end-of-stream, or the
+// failure that stopped delivery early. Both channels are closed once the
+// stream ends. Stream does not buffer the full result set in memory,
+// unlike List/ListAll.
+func (s *UsageService) Stream(ctx context.Context, params *UsageStreamParams) (<-chan UsageRecord, <-chan error) {
+	records := make(chan UsageRecord)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(records)
+		defer close(errs)
+		errs <- s.streamInto(ctx, params, records)
+	}()
+
+	return records, errs
+}
+
+func (s *UsageService) streamInto(ctx context.Context, params *UsageStreamParams, records chan<- UsageRecord) error {
+	req, err := s.client.newRequest(http.MethodGet, "/api/v1/usage/stream", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/x-ndjson")
+
+	if params != nil {
+		q := req.URL.Query()
+		if params.Range != "" {
+			q.Set("range", string(params.Range))
+		}
+		if params.StartTime != nil {
+			q.Set("start_time", params.StartTime.Format(time.RFC3339))
+		}
+		if params.EndTime != nil {
+			q.Set("end_time", params.EndTime.Format(time.RFC3339))
+		}
+		if params.OrganizationID != "" {
+			q.Set("organization_id", params.OrganizationID)
+		}
+		if params.ProjectID != "" {
+			q.Set("project_id", params.ProjectID)
+		}
+		if params.Provider != "" {
+			q.Set("provider", string(params.Provider))
+		}
+		if params.Model != "" {
+			q.Set("model", params.Model)
+		}
+		if params.AfterID != "" {
+			q.Set("after_id", params.AfterID)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := s.client.executeRequest(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return s.client.checkResponseStatus(resp.StatusCode, body)
+	}
+
+	var readDeadline *deadlineTimer
+	if params != nil && !params.ReadDeadline.IsZero() {
+		readDeadline = newDeadlineTimer()
+		readDeadline.set(params.ReadDeadline)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	lines := make(chan string)
+	scanErrs := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		scanErrs <- scanner.Err()
+	}()
+
+	for {
+		var deadlineC <-chan struct{}
+		if readDeadline != nil {
+			deadlineC = readDeadline.c()
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %v", ErrContextCanceled, ctx.Err())
+		case <-deadlineC:
+			return fmt.Errorf("%w: usage stream read deadline exceeded", ErrContextCanceled)
+		case line, ok := <-lines:
+			if !ok {
+				return <-scanErrs
+			}
+			if line == "" {
+				continue
+			}
+			var record UsageRecord
+			if err := json.Unmarshal([]byte(line), &record); err != nil {
+				return fmt.Errorf("%w: decoding usage stream record: %v", ErrServerError, err)
+			}
+			select {
+			case records <- record:
+			case <-ctx.Done():
+				return fmt.Errorf("%w: %v", ErrContextCanceled, ctx.Err())
+			case <-deadlineC:
+				return fmt.Errorf("%w: usage stream read deadline exceeded", ErrContextCanceled)
+			}
+		}
+	}
+}
+
+// DefaultIngestBatchSize is how many records IngestSession.Send buffers
+// before flushing to the connection when IngestSessionOptions.BatchSize
+// isn't set.
+const DefaultIngestBatchSize = 100
+
+// IngestSessionOptions configures an IngestSession.
+type IngestSessionOptions struct {
+	// BatchSize is how many records to buffer before flushing. Defaults to
+	// DefaultIngestBatchSize.
+	BatchSize int
+}
+
+func (o IngestSessionOptions) withDefaults() IngestSessionOptions {
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultIngestBatchSize
+	}
+	return o
+}
+
+// IngestSession is a batched front end over IngestStream, opened by
+// UsageService.BulkIngest. Send buffers records and flushes them onto the
+// underlying stream once BatchSize is reached; call Flush to send a
+// partial batch early, and Close to flush any remainder and wait for the
+// server's final IngestProgress acknowledgement.
+type IngestSession struct {
+	opts IngestSessionOptions
+
+	in       chan UsageRecord
+	progress <-chan IngestProgress
+	done     chan struct{} // closed once progress is fully drained
+
+	mu      sync.Mutex
+	buf     []UsageRecord
+	closed  bool
+	lastErr error
+
+	writeDeadline *deadlineTimer
+	readDeadline  *deadlineTimer
+}
+
+// BulkIngest opens an IngestSession backed by IngestStream, for callers
+// that want a simple Send-one-record-at-a-time API instead of driving the
+// channel IngestStream itself expects.
+func (s *UsageService) BulkIngest(ctx context.Context) (*IngestSession, error) {
+	return s.bulkIngest(ctx, IngestSessionOptions{})
+}
+
+func (s *UsageService) bulkIngest(ctx context.Context, opts IngestSessionOptions) (*IngestSession, error) {
+	in := make(chan UsageRecord)
+	progress, err := s.IngestStream(ctx, in, StreamOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	sess := &IngestSession{
+		opts:          opts.withDefaults(),
+		in:            in,
+		progress:      progress,
+		done:          make(chan struct{}),
+		writeDeadline: newDeadlineTimer(),
+	}
+
+	go func() {
+		defer close(sess.done)
+		for p := range progress {
+			if p.Err != "" {
+				sess.mu.Lock()
+				sess.lastErr = fmt.Errorf("%w: %s", ErrServerError, p.Err)
+				sess.mu.Unlock()
+			}
+		}
+	}()
+
+	return sess, nil
+}
+
+// Send buffers record, flushing the batch onto the underlying stream once
+// BatchSize records have accumulated.
+func (sess *IngestSession) Send(record UsageRecord) error {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	if sess.closed {
+		return fmt.Errorf("%w: ingest session is closed", ErrBadRequest)
+	}
+
+	sess.buf = append(sess.buf, record)
+	if len(sess.buf) < sess.opts.BatchSize {
+		return nil
+	}
+	return sess.flushLocked()
+}
+
+// Flush writes any buffered records to the stream immediately, without
+// waiting for a full batch.
+func (sess *IngestSession) Flush() error {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return sess.flushLocked()
+}
+
+func (sess *IngestSession) flushLocked() error {
+	for _, record := range sess.buf {
+		if err := sess.writeLocked(record); err != nil {
+			return err
+		}
+	}
+	sess.buf = sess.buf[:0]
+	return nil
+}
+
+// writeLocked sends record on the underlying IngestStream channel,
+// unblocking on the write deadline instead of hanging forever against a
+// stalled writer goroutine.
+func (sess *IngestSession) writeLocked(record UsageRecord) error {
+	select {
+	case sess.in <- record:
+		return nil
+	case <-sess.writeDeadline.c():
+		return fmt.Errorf("%w: ingest session write deadline exceeded", ErrContextCanceled)
+	}
+}
+
+// SetReadDeadline sets the deadline for Close to receive the stream's
+// final IngestProgress acknowledgement. A zero value disables the
+// deadline.
+func (sess *IngestSession) SetReadDeadline(t time.Time) {
+	// The read side is the final acknowledgement awaited by Close; reuse
+	// the same mechanism as writes via a second timer so Read/Write
+	// deadlines are independently configurable, matching net.Conn.
+	sess.readDeadlineOnce().set(t)
+}
+
+// SetWriteDeadline sets the deadline Send/Flush block against when
+// handing a record to the underlying stream. A zero value disables the
+// deadline.
+func (sess *IngestSession) SetWriteDeadline(t time.Time) {
+	sess.writeDeadline.set(t)
+}
+
+func (sess *IngestSession) readDeadlineOnce() *deadlineTimer {
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if sess.readDeadline == nil {
+		sess.readDeadline = newDeadlineTimer()
+	}
+	return sess.readDeadline
+}
+
+// Close flushes any buffered records, closes the underlying stream, and
+// waits for its final IngestProgress acknowledgement.
+func (sess *IngestSession) Close() error {
+	sess.mu.Lock()
+	if sess.closed {
+		sess.mu.Unlock()
+		return nil
+	}
+	sess.closed = true
+	flushErr := sess.flushLocked()
+	readDeadline := sess.readDeadline
+	sess.mu.Unlock()
+
+	close(sess.in)
+
+	var readDeadlineC <-chan struct{}
+	if readDeadline != nil {
+		readDeadlineC = readDeadline.c()
+	}
+
+	select {
+	case <-sess.done:
+		if flushErr != nil {
+			return flushErr
+		}
+		sess.mu.Lock()
+		defer sess.mu.Unlock()
+		return sess.lastErr
+	case <-readDeadlineC:
+		return fmt.Errorf("%w: ingest session read deadline exceeded", ErrContextCanceled)
+	}
+}
@@ -0,0 +1,303 @@
+package llmcostops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestUsageService_Stream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/usage/stream" {
+			t.Errorf("Expected /api/v1/usage/stream, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		for i := 0; i < 3; i++ {
+			json.NewEncoder(w).Encode(UsageRecord{ID: fmt.Sprintf("usage-%d", i)})
+		}
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	records, errs := client.Usage.Stream(context.Background(), nil)
+
+	var got []string
+	for record := range records {
+		got = append(got, record.ID)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+
+	want := []string{"usage-0", "usage-1", "usage-2"}
+	if len(got) != len(want) {
+		t.Fatalf("Stream() delivered %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("record[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestUsageService_Stream_AfterIDQueryParam(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("after_id"); got != "usage-1" {
+			t.Errorf("Expected after_id=usage-1, got %s", got)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	records, errs := client.Usage.Stream(context.Background(), &UsageStreamParams{AfterID: "usage-1"})
+	for range records {
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+}
+
+func TestUsageService_Stream_ReadDeadlineExceeded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		flusher, _ := w.(http.Flusher)
+		json.NewEncoder(w).Encode(UsageRecord{ID: "usage-0"})
+		if flusher != nil {
+			flusher.Flush()
+		}
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	records, errs := client.Usage.Stream(context.Background(), &UsageStreamParams{
+		ReadDeadline: time.Now().Add(20 * time.Millisecond),
+	})
+
+	for range records {
+	}
+	if err := <-errs; !isError(err, ErrContextCanceled) {
+		t.Errorf("expected ErrContextCanceled, got %v", err)
+	}
+}
+
+func TestUsageService_BulkIngest(t *testing.T) {
+	var received []UsageRecord
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/usage/ingest:stream" {
+			t.Errorf("Expected /api/v1/usage/ingest:stream, got %s", r.URL.Path)
+		}
+		dec := json.NewDecoder(r.Body)
+		for {
+			var record UsageRecord
+			if err := dec.Decode(&record); err != nil {
+				break
+			}
+			received = append(received, record)
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(IngestProgress{RecordsAccepted: int64(len(received)), Done: true})
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	sess, err := client.Usage.BulkIngest(context.Background())
+	if err != nil {
+		t.Fatalf("BulkIngest() error = %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := sess.Send(UsageRecord{ID: fmt.Sprintf("usage-%d", i)}); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	if err := sess.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if len(received) != 3 {
+		t.Fatalf("server received %d records, want 3", len(received))
+	}
+}
+
+func TestUsageService_BulkIngest_RejectsSendAfterClose(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(IngestProgress{Done: true})
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	sess, err := client.Usage.BulkIngest(context.Background())
+	if err != nil {
+		t.Fatalf("BulkIngest() error = %v", err)
+	}
+	if err := sess.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if err := sess.Send(UsageRecord{ID: "usage-0"}); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestExportService_StreamExport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/export/stream" {
+			t.Errorf("Expected /api/v1/export/stream, got %s", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("id,cost\n1,2.00\n"))
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	stream, err := client.Export.StreamExport(context.Background(), &ExportParams{Format: FormatCSV})
+	if err != nil {
+		t.Fatalf("StreamExport() error = %v", err)
+	}
+	defer stream.Close()
+
+	data, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("reading stream: %v", err)
+	}
+	if string(data) != "id,cost\n1,2.00\n" {
+		t.Errorf("StreamExport() body = %q", data)
+	}
+}
+
+func TestExportService_StreamExport_RejectsUnsupportedFormat(t *testing.T) {
+	client := setupTestClient(t, "http://example.invalid")
+	defer client.Close()
+
+	if _, err := client.Export.StreamExport(context.Background(), &ExportParams{Format: FormatJSON}); !isError(err, ErrBadRequest) {
+		t.Errorf("expected ErrBadRequest, got %v", err)
+	}
+}
+
+func TestExportService_ExportStream_ReportsProgress(t *testing.T) {
+	const payload = "id,cost\n1,2.00\n2,3.00\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/export" {
+			t.Errorf("Expected /api/v1/export, got %s", r.URL.Path)
+		}
+		w.Header().Set("ETag", `"rev-1"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	var lastRead, lastTotal int64
+	var calls int
+	stream, meta, err := client.Export.ExportStream(context.Background(), &ExportParams{Format: FormatCSV}, &ExportStreamOptions{
+		OnProgress: func(bytesRead, total int64) {
+			calls++
+			lastRead, lastTotal = bytesRead, total
+		},
+	})
+	if err != nil {
+		t.Fatalf("ExportStream() error = %v", err)
+	}
+	defer stream.Close()
+
+	data, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("reading stream: %v", err)
+	}
+	if string(data) != payload {
+		t.Errorf("ExportStream() body = %q", data)
+	}
+	if meta.ETag != `"rev-1"` {
+		t.Errorf("meta.ETag = %q, want \"rev-1\"", meta.ETag)
+	}
+	if calls == 0 {
+		t.Fatal("expected OnProgress to be called at least once")
+	}
+	if lastRead != int64(len(payload)) {
+		t.Errorf("final bytesRead = %d, want %d", lastRead, len(payload))
+	}
+	if lastTotal != meta.ContentLength {
+		t.Errorf("final total = %d, want %d", lastTotal, meta.ContentLength)
+	}
+}
+
+func TestExportService_ExportStream_Resume(t *testing.T) {
+	const full = "id,cost\n1,2.00\n2,3.00\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rng := r.Header.Get("Range")
+		if rng == "" {
+			t.Fatalf("expected a Range header on resume")
+		}
+		if got, want := r.Header.Get("If-Range"), `"rev-1"`; got != want {
+			t.Errorf("If-Range = %q, want %q", got, want)
+		}
+		w.Header().Set("ETag", `"rev-1"`)
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write([]byte(full[8:]))
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	stream, _, err := client.Export.ExportStream(context.Background(), &ExportParams{Format: FormatCSV}, &ExportStreamOptions{
+		Resume: &ExportResumeToken{Offset: 8, ETag: `"rev-1"`},
+	})
+	if err != nil {
+		t.Fatalf("ExportStream() error = %v", err)
+	}
+	defer stream.Close()
+
+	data, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("reading stream: %v", err)
+	}
+	if string(data) != full[8:] {
+		t.Errorf("ExportStream() body = %q, want %q", data, full[8:])
+	}
+}
+
+func TestExportService_ExportToWriter_Streams(t *testing.T) {
+	const payload = "id,cost\n1,2.00\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t, server.URL)
+	defer client.Close()
+
+	var buf bytes.Buffer
+	if err := client.Export.ExportToWriter(context.Background(), &ExportParams{Format: FormatCSV}, &buf); err != nil {
+		t.Fatalf("ExportToWriter() error = %v", err)
+	}
+	if buf.String() != payload {
+		t.Errorf("ExportToWriter() wrote %q, want %q", buf.String(), payload)
+	}
+}
@@ -34,6 +34,21 @@ type UsageListParams struct {
 	PaginationParams
 }
 
+// MaxExemplarsPerBucket bounds UsageStatsParams.ExemplarsPerBucket to
+// prevent a caller from accidentally requesting a full scan of every record
+// behind a bucket.
+const MaxExemplarsPerBucket = 20
+
+// ExemplarSelector chooses which records within a bucket are surfaced as
+// exemplars.
+type ExemplarSelector string
+
+const (
+	ExemplarTopCost    ExemplarSelector = "top_cost"
+	ExemplarP99Latency ExemplarSelector = "p99_latency"
+	ExemplarMaxTokens  ExemplarSelector = "max_tokens"
+)
+
 // UsageStatsParams represents parameters for usage statistics
 type UsageStatsParams struct {
 	Range          TimeRange  `json:"range,omitempty"`
@@ -42,6 +57,34 @@ type UsageStatsParams struct {
 	OrganizationID string     `json:"organization_id,omitempty"`
 	ProjectID      string     `json:"project_id,omitempty"`
 	GroupBy        []string   `json:"group_by,omitempty"` // e.g., "provider", "model", "project"
+
+	// WithExemplars requests that each bucket/group include up to
+	// ExemplarsPerBucket representative UsageExemplars, chosen by
+	// ExemplarSelector.
+	WithExemplars      bool             `json:"with_exemplars,omitempty"`
+	ExemplarSelector   ExemplarSelector `json:"exemplar_selector,omitempty"`
+	ExemplarsPerBucket int              `json:"exemplars_per_bucket,omitempty"`
+}
+
+func (p *UsageStatsParams) validate() error {
+	if p.ExemplarsPerBucket > MaxExemplarsPerBucket {
+		return fmt.Errorf("%w: exemplars_per_bucket %d exceeds MaxExemplarsPerBucket (%d)", ErrBadRequest, p.ExemplarsPerBucket, MaxExemplarsPerBucket)
+	}
+	return nil
+}
+
+// UsageExemplar is a single representative request backing a bucket or
+// group in UsageStats/UsageStatsSeries, letting a dashboard drill down from
+// an anomalous cost spike to the exact prompt/completion record via
+// UsageService.Get(ctx, exemplar.RecordID).
+type UsageExemplar struct {
+	RecordID  string            `json:"record_id"`
+	Timestamp time.Time         `json:"timestamp"`
+	Tokens    int64             `json:"tokens"`
+	CostUSD   string            `json:"cost_usd"`
+	LatencyMs int64             `json:"latency_ms,omitempty"`
+	TraceID   string            `json:"trace_id,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
 }
 
 // UsageStats represents aggregated usage statistics
@@ -55,30 +98,34 @@ type UsageStats struct {
 	ByProvider        map[Provider]*ProviderStats `json:"by_provider,omitempty"`
 	ByModel           map[string]*ModelStats      `json:"by_model,omitempty"`
 	ByProject         map[string]*ProjectStats    `json:"by_project,omitempty"`
+	Exemplars         []UsageExemplar             `json:"exemplars,omitempty"`
 }
 
 // ProviderStats represents provider-level statistics
 type ProviderStats struct {
-	Requests     int64 `json:"requests"`
-	PromptTokens int64 `json:"prompt_tokens"`
-	CompTokens   int64 `json:"completion_tokens"`
-	TotalTokens  int64 `json:"total_tokens"`
+	Requests     int64           `json:"requests"`
+	PromptTokens int64           `json:"prompt_tokens"`
+	CompTokens   int64           `json:"completion_tokens"`
+	TotalTokens  int64           `json:"total_tokens"`
+	Exemplars    []UsageExemplar `json:"exemplars,omitempty"`
 }
 
 // ModelStats represents model-level statistics
 type ModelStats struct {
-	Requests     int64 `json:"requests"`
-	PromptTokens int64 `json:"prompt_tokens"`
-	CompTokens   int64 `json:"completion_tokens"`
-	TotalTokens  int64 `json:"total_tokens"`
+	Requests     int64           `json:"requests"`
+	PromptTokens int64           `json:"prompt_tokens"`
+	CompTokens   int64           `json:"completion_tokens"`
+	TotalTokens  int64           `json:"total_tokens"`
+	Exemplars    []UsageExemplar `json:"exemplars,omitempty"`
 }
 
 // ProjectStats represents project-level statistics
 type ProjectStats struct {
-	Requests     int64 `json:"requests"`
-	PromptTokens int64 `json:"prompt_tokens"`
-	CompTokens   int64 `json:"completion_tokens"`
-	TotalTokens  int64 `json:"total_tokens"`
+	Requests     int64           `json:"requests"`
+	PromptTokens int64           `json:"prompt_tokens"`
+	CompTokens   int64           `json:"completion_tokens"`
+	TotalTokens  int64           `json:"total_tokens"`
+	Exemplars    []UsageExemplar `json:"exemplars,omitempty"`
 }
 
 // Ingest ingests one or more usage records
@@ -172,8 +219,39 @@ func (s *UsageService) List(ctx context.Context, params *UsageListParams) ([]Usa
 	return result, nil
 }
 
+// ListIterator returns an Iterator over usage records matching params,
+// fetching additional pages from List as the caller consumes them. params
+// may be nil; its Page/PageSize are overwritten as the iterator pages
+// through the list, so a zero PageSize just selects the default page size.
+func (s *UsageService) ListIterator(params *UsageListParams) *Iterator[UsageRecord] {
+	p := UsageListParams{}
+	if params != nil {
+		p = *params
+	}
+	pageSize := p.PageSize
+
+	return NewIterator(pageSize, func(ctx context.Context, page, pageSize int) ([]UsageRecord, error) {
+		pageParams := p
+		pageParams.Page = page
+		pageParams.PageSize = pageSize
+		return s.List(ctx, &pageParams)
+	})
+}
+
+// ListAll drains ListIterator into a single slice, fetching every page of
+// usage records matching params.
+func (s *UsageService) ListAll(ctx context.Context, params *UsageListParams) ([]UsageRecord, error) {
+	return All(ctx, s.ListIterator(params))
+}
+
 // Stats retrieves aggregated usage statistics
 func (s *UsageService) Stats(ctx context.Context, params *UsageStatsParams) (*UsageStats, error) {
+	if params != nil {
+		if err := params.validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	req, err := s.client.newRequest(http.MethodGet, "/api/v1/usage/stats", nil)
 	if err != nil {
 		return nil, err
@@ -200,6 +278,15 @@ func (s *UsageService) Stats(ctx context.Context, params *UsageStatsParams) (*Us
 		for _, groupBy := range params.GroupBy {
 			q.Add("group_by", groupBy)
 		}
+		if params.WithExemplars {
+			q.Set("with_exemplars", "true")
+			if params.ExemplarSelector != "" {
+				q.Set("exemplar_selector", string(params.ExemplarSelector))
+			}
+			if params.ExemplarsPerBucket > 0 {
+				q.Set("exemplars_per_bucket", fmt.Sprintf("%d", params.ExemplarsPerBucket))
+			}
+		}
 		req.URL.RawQuery = q.Encode()
 	}
 
@@ -0,0 +1,151 @@
+package llmcostops
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// MaxStatsRangeSamples bounds how many samples a single StatsRange call may
+// request, guarding against accidental full-table scans from a tiny Step
+// over a huge Start/End window.
+const MaxStatsRangeSamples = 11000
+
+// UsageStatsRangeParams requests a time-bucketed series of usage statistics,
+// modelled on Prometheus's query_range API.
+type UsageStatsRangeParams struct {
+	Start          time.Time     `json:"start"`
+	End            time.Time     `json:"end"`
+	Step           time.Duration `json:"step"`
+	OrganizationID string        `json:"organization_id,omitempty"`
+	ProjectID      string        `json:"project_id,omitempty"`
+	GroupBy        []string      `json:"group_by,omitempty"`
+
+	WithExemplars      bool             `json:"with_exemplars,omitempty"`
+	ExemplarSelector   ExemplarSelector `json:"exemplar_selector,omitempty"`
+	ExemplarsPerBucket int              `json:"exemplars_per_bucket,omitempty"`
+}
+
+func (p *UsageStatsRangeParams) validate() error {
+	if p.Step <= 0 {
+		return fmt.Errorf("%w: step must be positive", ErrBadRequest)
+	}
+	if !p.End.After(p.Start) {
+		return fmt.Errorf("%w: end must be after start", ErrBadRequest)
+	}
+
+	samples := int64(p.End.Sub(p.Start) / p.Step)
+	if samples > MaxStatsRangeSamples {
+		return fmt.Errorf("%w: (end-start)/step = %d samples exceeds MaxStatsRangeSamples (%d)", ErrBadRequest, samples, MaxStatsRangeSamples)
+	}
+
+	if p.ExemplarsPerBucket > MaxExemplarsPerBucket {
+		return fmt.Errorf("%w: exemplars_per_bucket %d exceeds MaxExemplarsPerBucket (%d)", ErrBadRequest, p.ExemplarsPerBucket, MaxExemplarsPerBucket)
+	}
+
+	return nil
+}
+
+// alignToStep rounds t down to the nearest step boundary since the Unix
+// epoch, matching Prometheus's query_range alignment behavior.
+func alignToStep(t time.Time, step time.Duration) time.Time {
+	ns := t.UnixNano()
+	rem := ns % int64(step)
+	if rem < 0 {
+		rem += int64(step)
+	}
+	return time.Unix(0, ns-rem).In(t.Location())
+}
+
+// UsageStatsSample is a single bucket in a UsageStatsSeries.
+type UsageStatsSample struct {
+	Timestamp    time.Time       `json:"timestamp"`
+	Requests     int64           `json:"requests"`
+	PromptTokens int64           `json:"prompt_tokens"`
+	CompTokens   int64           `json:"completion_tokens"`
+	TotalTokens  int64           `json:"total_tokens"`
+	CostUSD      string          `json:"cost_usd"`
+	Exemplars    []UsageExemplar `json:"exemplars,omitempty"`
+}
+
+// UsageStatsGroup is one group key's samples, aligned to the requested step.
+type UsageStatsGroup struct {
+	Labels  map[string]string  `json:"labels"`
+	Samples []UsageStatsSample `json:"samples"`
+}
+
+// UsageStatsResultType discriminates a UsageStatsSeries shape, mirroring
+// the Prometheus HTTP API's "matrix" vs "vector" result types.
+type UsageStatsResultType string
+
+const (
+	// ResultTypeMatrix indicates each group carries a series of samples
+	// over the requested range.
+	ResultTypeMatrix UsageStatsResultType = "matrix"
+
+	// ResultTypeVector indicates each group carries a single sample (e.g.
+	// Start == End).
+	ResultTypeVector UsageStatsResultType = "vector"
+)
+
+// UsageStatsSeries is the response from StatsRange: per group key, a slice
+// of samples aligned to the requested step.
+type UsageStatsSeries struct {
+	ResultType UsageStatsResultType `json:"result_type"`
+	Step       time.Duration        `json:"step"`
+	Groups     []UsageStatsGroup    `json:"groups"`
+}
+
+// StatsRange retrieves step-bucketed usage statistics over [Start, End),
+// suitable for rendering cost-over-time sparklines without client-side
+// bucketing. Start and End are aligned to whole Step boundaries the way
+// Prometheus's query_range does.
+func (s *UsageService) StatsRange(ctx context.Context, params *UsageStatsRangeParams) (*UsageStatsSeries, error) {
+	if params == nil {
+		return nil, fmt.Errorf("%w: params cannot be nil", ErrBadRequest)
+	}
+	if err := params.validate(); err != nil {
+		return nil, err
+	}
+
+	aligned := *params
+	aligned.Start = alignToStep(params.Start, params.Step)
+	aligned.End = alignToStep(params.End, params.Step)
+
+	req, err := s.client.newRequest(http.MethodGet, "/api/v1/usage/stats/range", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	q := req.URL.Query()
+	q.Set("start", aligned.Start.Format(time.RFC3339))
+	q.Set("end", aligned.End.Format(time.RFC3339))
+	q.Set("step", aligned.Step.String())
+	if aligned.OrganizationID != "" {
+		q.Set("organization_id", aligned.OrganizationID)
+	}
+	if aligned.ProjectID != "" {
+		q.Set("project_id", aligned.ProjectID)
+	}
+	for _, groupBy := range aligned.GroupBy {
+		q.Add("group_by", groupBy)
+	}
+	if aligned.WithExemplars {
+		q.Set("with_exemplars", "true")
+		if aligned.ExemplarSelector != "" {
+			q.Set("exemplar_selector", string(aligned.ExemplarSelector))
+		}
+		if aligned.ExemplarsPerBucket > 0 {
+			q.Set("exemplars_per_bucket", fmt.Sprintf("%d", aligned.ExemplarsPerBucket))
+		}
+	}
+	req.URL.RawQuery = q.Encode()
+
+	var result UsageStatsSeries
+	if err := s.client.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
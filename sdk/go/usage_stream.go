@@ -0,0 +1,161 @@
+package llmcostops
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// StreamOptions configures a streaming ingest initiated by IngestStream.
+type StreamOptions struct {
+	// CheckpointID, if set, resumes a previously interrupted stream starting
+	// after the last byte offset the server acknowledged for that ID. Leave
+	// empty to start a new checkpoint; the ID used is returned on the first
+	// IngestProgress event.
+	CheckpointID string
+
+	// ProgressBufferSize bounds how many IngestProgress events are buffered
+	// before the SDK stops writing further records to the socket, giving
+	// callers backpressure simply by not reading the channel. Defaults to 16.
+	ProgressBufferSize int
+}
+
+// IngestProgress reports incremental server-side acknowledgement of a
+// streaming ingest.
+type IngestProgress struct {
+	CheckpointID    string            `json:"checkpoint_id"`
+	RecordsAccepted int64             `json:"records_accepted"`
+	RecordsRejected int64             `json:"records_rejected"`
+	Rejections      []IngestRejection `json:"rejections,omitempty"`
+	ByteOffset      int64             `json:"byte_offset"`
+	Done            bool              `json:"done"`
+	Err             string            `json:"error,omitempty"`
+}
+
+// IngestRejection describes why a single record in a streaming ingest was
+// rejected by the server.
+type IngestRejection struct {
+	RecordID string `json:"record_id,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+// IngestStream ingests records read off in as a chunked, newline-delimited
+// JSON POST to /api/v1/usage/ingest:stream. It returns a channel of
+// IngestProgress events mirroring the server's acknowledgements; the SDK
+// stops writing further records once the progress channel's buffer is full,
+// so a caller that wants to bound memory use simply reads slowly.
+//
+// The returned channel is closed once in is drained and the server has
+// acknowledged (or failed) the final record, or when ctx is canceled.
+func (s *UsageService) IngestStream(ctx context.Context, in <-chan UsageRecord, opts StreamOptions) (<-chan IngestProgress, error) {
+	if opts.ProgressBufferSize <= 0 {
+		opts.ProgressBufferSize = 16
+	}
+
+	pr, pw := io.Pipe()
+	progress := make(chan IngestProgress, opts.ProgressBufferSize)
+
+	req, err := s.client.newRequest(http.MethodPost, "/api/v1/usage/ingest:stream", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	req.Header.Set("Transfer-Encoding", "chunked")
+	if opts.CheckpointID != "" {
+		req.Header.Set("X-Checkpoint-ID", opts.CheckpointID)
+	}
+	req.Body = pr
+	req.ContentLength = -1
+
+	if err := s.client.authenticator.Apply(ctx, req); err != nil {
+		return nil, fmt.Errorf("failed to authenticate request: %w", err)
+	}
+	req.Header.Set("User-Agent", UserAgent)
+
+	go func() {
+		enc := json.NewEncoder(pw)
+		for {
+			// Stop writing further records once the progress channel's
+			// buffer is full, so a slow-reading caller bounds the SDK's
+			// memory use instead of the socket buffering unboundedly.
+			for len(progress) >= cap(progress) {
+				select {
+				case <-ctx.Done():
+					pw.CloseWithError(ctx.Err())
+					return
+				case <-time.After(time.Millisecond):
+				}
+			}
+
+			select {
+			case rec, ok := <-in:
+				if !ok {
+					pw.Close()
+					return
+				}
+				if err := enc.Encode(rec); err != nil {
+					pw.CloseWithError(fmt.Errorf("failed to encode record: %w", err))
+					return
+				}
+			case <-ctx.Done():
+				pw.CloseWithError(ctx.Err())
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer close(progress)
+
+		resp, err := s.client.httpClient.Do(req.WithContext(ctx))
+		if err != nil {
+			progress <- IngestProgress{Err: err.Error(), Done: true}
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			body, _ := io.ReadAll(resp.Body)
+			progress <- IngestProgress{Err: fmt.Sprintf("ingest stream failed with status %d: %s", resp.StatusCode, string(body)), Done: true}
+			return
+		}
+
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			var p IngestProgress
+			if err := json.Unmarshal(line, &p); err != nil {
+				continue
+			}
+			select {
+			case progress <- p:
+			case <-ctx.Done():
+				return
+			}
+			if p.Done {
+				return
+			}
+		}
+	}()
+
+	return progress, nil
+}
+
+// Resume continues a streaming ingest that was interrupted, picking up
+// after the last offset the server acknowledged for checkpointID. Records
+// already acknowledged before the interruption are not replayed.
+func (s *UsageService) Resume(ctx context.Context, checkpointID string, in <-chan UsageRecord) (<-chan IngestProgress, error) {
+	if checkpointID == "" {
+		return nil, fmt.Errorf("%w: checkpoint ID is required", ErrBadRequest)
+	}
+	return s.IngestStream(ctx, in, StreamOptions{CheckpointID: checkpointID})
+}
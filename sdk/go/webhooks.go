@@ -0,0 +1,313 @@
+package llmcostops
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType identifies a kind of event a webhook subscription or
+// Client.OnEvent handler can receive.
+type EventType string
+
+const (
+	// EventPricingAdded fires when PricingService.Add succeeds.
+	EventPricingAdded EventType = "pricing.added"
+
+	// EventPricingSuperseded fires when a new pricing window closes out an
+	// existing one's open-ended EndDate.
+	EventPricingSuperseded EventType = "pricing.superseded"
+
+	// EventPricingDeleted fires when PricingService.Delete succeeds.
+	EventPricingDeleted EventType = "pricing.deleted"
+
+	// EventBudgetThresholdCrossed fires when RulesService.ForecastBudgetRule
+	// projects a budget rule's spend to cross its SoftThreshold or
+	// HardThreshold before its window closes.
+	EventBudgetThresholdCrossed EventType = "budget.threshold_crossed"
+
+	// EventUsageAnomalyDetected fires for each Anomaly AnomaliesService.Detect
+	// flags in a cost series.
+	EventUsageAnomalyDetected EventType = "usage.anomaly_detected"
+)
+
+// WebhookEvent is a single event, delivered both to in-process handlers
+// registered via Client.OnEvent and, for matching subscriptions, as the
+// signed body of an HTTP POST from WebhooksService.
+type WebhookEvent struct {
+	// ID increases monotonically within a process and is echoed in the
+	// X-Event-Id header of webhook deliveries, letting subscribers dedupe
+	// retried deliveries.
+	ID        int64       `json:"id"`
+	Type      EventType   `json:"type"`
+	Timestamp time.Time   `json:"timestamp"`
+	Payload   interface{} `json:"payload"`
+}
+
+// WebhookConfig describes a subscriber endpoint to deliver matching events
+// to.
+type WebhookConfig struct {
+	URL    string
+	Events []EventType
+	Secret string
+}
+
+// WebhookSubscription is the client's record of a registered WebhookConfig.
+type WebhookSubscription struct {
+	ID        string
+	Config    WebhookConfig
+	CreatedAt time.Time
+}
+
+// FailedDelivery records a webhook delivery that exhausted its retry budget
+// without a 2xx response.
+type FailedDelivery struct {
+	SubscriptionID string
+	Event          WebhookEvent
+	Attempts       int
+	LastErr        string
+	FailedAt       time.Time
+}
+
+// webhookRecvWindow bounds how long after Timestamp a delivery's signature
+// remains valid, guarding against replay of a captured request. It is sent
+// to the subscriber as X-Event-Recv-Window so they can enforce the same
+// check.
+const webhookRecvWindow = 5 * time.Minute
+
+// webhookMaxAttempts is how many delivery attempts WebhooksService makes,
+// with exponential backoff between attempts, before giving up and recording
+// a FailedDelivery.
+const webhookMaxAttempts = 5
+
+// WebhooksService dispatches events the SDK observes (see EventType) to
+// subscriber URLs registered via Register, signing each delivery with an
+// HMAC-SHA256 derived from the subscription's secret. Unlike AlertService,
+// which manages server-side alert rules and documents how to validate an
+// inbound delivery from the LLM Cost Ops backend, WebhooksService is a
+// client-local dispatcher: the SDK itself owns delivery, retries, and the
+// dead-letter queue, for embedders that want push notifications without
+// standing up a receiver for AlertService's webhook mechanism.
+type WebhooksService struct {
+	client *Client
+
+	nextEventID atomic.Int64
+
+	mu            sync.Mutex
+	subscriptions []*WebhookSubscription
+	failed        []FailedDelivery
+}
+
+// eventHandler is a single OnEvent registration, identified by id so
+// unsubscribe can remove exactly this one even if the same handler value
+// was registered more than once.
+type eventHandler struct {
+	id      int64
+	handler func(WebhookEvent)
+}
+
+// OnEvent registers handler to be called, synchronously and in-process,
+// whenever the SDK emits an event of eventType (see EventType). It returns
+// an unsubscribe function that removes the handler; calling it more than
+// once is a no-op. Unlike WebhooksService.Register, OnEvent never makes a
+// network call — it's for embedders that want to react to SDK-observed
+// events (e.g. a newly added PricingService entry) without standing up an
+// HTTP receiver.
+func (c *Client) OnEvent(eventType EventType, handler func(WebhookEvent)) (unsubscribe func()) {
+	id := atomic.AddInt64(&c.nextHandlerID, 1)
+
+	c.eventMu.Lock()
+	if c.eventHandlers == nil {
+		c.eventHandlers = make(map[EventType][]*eventHandler)
+	}
+	c.eventHandlers[eventType] = append(c.eventHandlers[eventType], &eventHandler{id: id, handler: handler})
+	c.eventMu.Unlock()
+
+	return func() {
+		c.eventMu.Lock()
+		defer c.eventMu.Unlock()
+		handlers := c.eventHandlers[eventType]
+		for i, h := range handlers {
+			if h.id == id {
+				c.eventHandlers[eventType] = append(handlers[:i], handlers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// emitLocalEvent invokes every handler registered for event.Type.
+func (c *Client) emitLocalEvent(event WebhookEvent) {
+	c.eventMu.RLock()
+	handlers := append([]*eventHandler(nil), c.eventHandlers[event.Type]...)
+	c.eventMu.RUnlock()
+
+	for _, h := range handlers {
+		h.handler(event)
+	}
+}
+
+// Register adds a subscriber endpoint that receives signed, retried POSTs
+// for the given WebhookConfig.Events.
+func (s *WebhooksService) Register(ctx context.Context, config WebhookConfig) (*WebhookSubscription, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("%w: url is required", ErrBadRequest)
+	}
+	if len(config.Events) == 0 {
+		return nil, fmt.Errorf("%w: at least one event is required", ErrBadRequest)
+	}
+	if config.Secret == "" {
+		return nil, fmt.Errorf("%w: secret is required", ErrBadRequest)
+	}
+
+	sub := &WebhookSubscription{
+		ID:        uuid.NewString(),
+		Config:    config,
+		CreatedAt: time.Now(),
+	}
+
+	s.mu.Lock()
+	s.subscriptions = append(s.subscriptions, sub)
+	s.mu.Unlock()
+
+	return sub, nil
+}
+
+// ListFailed returns deliveries that exhausted their retry budget, oldest
+// first, for callers that want to replay or alert on them.
+func (s *WebhooksService) ListFailed(ctx context.Context) ([]FailedDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	failed := make([]FailedDelivery, len(s.failed))
+	copy(failed, s.failed)
+	return failed, nil
+}
+
+// emit fans eventType/payload out to every OnEvent handler and every
+// registered subscription whose Events include eventType. Handlers run
+// synchronously on the caller's goroutine; deliveries run in their own
+// goroutine since they may block through several retries.
+func (s *WebhooksService) emit(eventType EventType, payload interface{}) {
+	event := WebhookEvent{
+		ID:        s.nextEventID.Add(1),
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Payload:   payload,
+	}
+
+	s.client.emitLocalEvent(event)
+
+	s.mu.Lock()
+	subs := make([]*WebhookSubscription, 0, len(s.subscriptions))
+	for _, sub := range s.subscriptions {
+		for _, et := range sub.Config.Events {
+			if et == eventType {
+				subs = append(subs, sub)
+				break
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	for _, sub := range subs {
+		go s.deliver(sub, event)
+	}
+}
+
+// deliver POSTs event to sub.Config.URL, making up to webhookMaxAttempts
+// attempts with exponential backoff and jitter between them before
+// recording a FailedDelivery.
+func (s *WebhooksService) deliver(sub *WebhookSubscription, event WebhookEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		s.recordFailure(sub, event, 0, err)
+		return
+	}
+
+	backoff := 100 * time.Millisecond
+	var lastErr error
+	for attempt := 0; attempt < webhookMaxAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff + time.Duration(rand.Int63n(int64(backoff)+1)))
+			backoff *= 2
+			if backoff > 30*time.Second {
+				backoff = 30 * time.Second
+			}
+		}
+
+		if lastErr = s.post(sub, event, body); lastErr == nil {
+			return
+		}
+	}
+
+	s.recordFailure(sub, event, webhookMaxAttempts, lastErr)
+}
+
+// post makes a single delivery attempt.
+func (s *WebhooksService) post(sub *WebhookSubscription, event WebhookEvent, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, sub.Config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Id", fmt.Sprintf("%d", event.ID))
+	req.Header.Set("X-Event-Timestamp", event.Timestamp.Format(time.RFC3339))
+	req.Header.Set("X-Event-Recv-Window", webhookRecvWindow.String())
+	req.Header.Set("X-Event-Signature", signWebhookDelivery(sub.Config.Secret, event, webhookRecvWindow, body))
+
+	httpClient := s.client.httpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook delivery failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *WebhooksService) recordFailure(sub *WebhookSubscription, event WebhookEvent, attempts int, err error) {
+	errMsg := ""
+	if err != nil {
+		errMsg = err.Error()
+	}
+	s.mu.Lock()
+	s.failed = append(s.failed, FailedDelivery{
+		SubscriptionID: sub.ID,
+		Event:          event,
+		Attempts:       attempts,
+		LastErr:        errMsg,
+		FailedAt:       time.Now(),
+	})
+	s.mu.Unlock()
+}
+
+// signWebhookDelivery computes the HMAC-SHA256 signature sent as
+// X-Event-Signature, following the canonical-string pattern other signed
+// request schemes in this SDK use (see auth.HMACKeyEmail): the timestamp,
+// the subscription's event ID, the receive window, and the payload are
+// concatenated into a single string and HMAC'd with the subscriber's
+// secret, then hex-encoded.
+func signWebhookDelivery(secret string, event WebhookEvent, recvWindow time.Duration, payload []byte) string {
+	canonical := fmt.Sprintf("%d%d%d%s", event.Timestamp.Unix(), event.ID, recvWindow.Milliseconds(), payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(canonical))
+	return hex.EncodeToString(mac.Sum(nil))
+}